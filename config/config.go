@@ -2,8 +2,20 @@ package config
 
 import (
 	"flag"
+	"log/slog"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
-	"log"
+	"github.com/trancecho/mundo-points-system/pkg/logger"
+)
+
+// Mode 运行模式：prod、docker 或 dev
+var Mode string
+
+var (
+	reloadMu    sync.Mutex
+	reloadHooks []func()
 )
 
 // InitConfig 初始化配置
@@ -11,6 +23,7 @@ func InitConfig() {
 	// 分不同模式加载配置文件
 	mode := flag.String("mode", "dev", "运行模式")
 	flag.Parse()
+	Mode = *mode
 	if *mode == "prod" {
 		viper.SetConfigName("config.prod")
 	} else if *mode == "docker" {
@@ -18,11 +31,32 @@ func InitConfig() {
 	} else if *mode == "dev" {
 		viper.SetConfigName("config.dev")
 	} else {
-		log.Fatalf("Invalid mode: %s", *mode)
+		logger.Fatal("Invalid mode", "mode", *mode)
 	}
 	viper.SetConfigType("yaml")
 	viper.AddConfigPath("config")
 	if err := viper.ReadInConfig(); err != nil {
-		log.Fatalf("Error reading config file: %v", err)
+		logger.Fatal("Error reading config file", "error", err)
 	}
 }
+
+// OnReload 注册配置文件变更后的回调，viper 只支持一个回调，各模块统一通过这里注册
+func OnReload(hook func()) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	reloadHooks = append(reloadHooks, hook)
+}
+
+// WatchConfig 监听配置文件变更，变更后依次执行已注册的回调
+func WatchConfig() {
+	viper.OnConfigChange(func(e fsnotify.Event) {
+		slog.Info("配置文件已变更", "file", e.Name)
+		reloadMu.Lock()
+		hooks := append([]func(){}, reloadHooks...)
+		reloadMu.Unlock()
+		for _, hook := range hooks {
+			hook()
+		}
+	})
+	viper.WatchConfig()
+}
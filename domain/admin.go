@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/trancecho/mundo-points-system/pkg/meta"
+	"github.com/trancecho/mundo-points-system/pkg/metrics"
+	"github.com/trancecho/mundo-points-system/pkg/tracing"
+	"github.com/trancecho/mundo-points-system/pkg/utils"
+	"github.com/trancecho/mundo-points-system/po"
+	v1 "github.com/trancecho/mundo-points-system/proto/point/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultAuditPageSize = 20
+	maxAuditPageSize     = 100
+	// 与 po.AdminAuditRecord 的列长度一致，按字符计算
+	maxAuditReasonLength = 512
+	maxAuditTicketLength = 128
+)
+
+// AdminAdjustPoints 管理员调整任意用户的积分和经验，操作人取自登录信息并写入审计记录
+// 管理员权限由 interceptors.AuthzPolicy 检查
+func (s *UserService) AdminAdjustPoints(ctx context.Context, req *v1.AdminAdjustPointsRequest) (*v1.AdminAdjustPointsResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.AdminAdjustPoints")
+	defer span.End()
+
+	_, err := meta.GetMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userClaims, ok := ctx.Value("claims").(*utils.Claims)
+	if !ok {
+		return nil, status.Errorf(400, "failed to get user claims from context")
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	ticket := strings.TrimSpace(req.Ticket)
+	if req.UserId == "" || reason == "" || ticket == "" {
+		return &v1.AdminAdjustPointsResponse{
+			Success:   false,
+			Message:   "用户、调整原因和工单号不能为空",
+			ErrorCode: v1.ErrorCode_INVALID_REQUEST,
+		}, nil
+	}
+	if utf8.RuneCountInString(reason) > maxAuditReasonLength || utf8.RuneCountInString(ticket) > maxAuditTicketLength {
+		return &v1.AdminAdjustPointsResponse{
+			Success:   false,
+			Message:   "调整原因不能超过512个字符，工单号不能超过128个字符",
+			ErrorCode: v1.ErrorCode_INVALID_REQUEST,
+		}, nil
+	}
+	if req.DeltaPoints == 0 && req.DeltaExperience == 0 {
+		return &v1.AdminAdjustPointsResponse{
+			Success:   false,
+			Message:   "积分和经验变化量不能都为0",
+			ErrorCode: v1.ErrorCode_INVALID_REQUEST,
+		}, nil
+	}
+
+	audit := &po.AdminAuditRecord{
+		OperatorID: strconv.FormatInt(userClaims.UserID, 10),
+		UserID:     req.UserId,
+		Points:     req.DeltaPoints,
+		Experience: req.DeltaExperience,
+		Reason:     reason,
+		Ticket:     ticket,
+	}
+	err = s.pointRepo.AdminAdjustPoints(ctx, audit)
+	switch {
+	case errors.Is(err, po.ErrPointsInsufficient):
+		metrics.ObservePointsInsufficient(v1.UserService_AdminAdjustPoints_FullMethodName)
+		return &v1.AdminAdjustPointsResponse{
+			Success:   false,
+			Message:   "积分不足",
+			ErrorCode: v1.ErrorCode_POINTS_INSUFFICIENT,
+		}, nil
+	case errors.Is(err, po.ErrUserNotFound):
+		return nil, status.Errorf(codes.NotFound, "用户不存在")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "调整积分失败: %v", err)
+	}
+
+	resp := &v1.AdminAdjustPointsResponse{
+		Success:   true,
+		Message:   "调整积分成功",
+		ErrorCode: v1.ErrorCode_NONE_ERROR,
+		AuditId:   audit.ID,
+		RecordId:  audit.PointRecordID,
+	}
+	//如果有经验变更，则可能需要更新等级
+	if req.DeltaExperience != 0 {
+		oldLevel, newLevel, err := s.userRepo.UpdateLevelByExperience(ctx, req.UserId)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "更新等级失败: %v", err)
+		}
+		resp.LevelChange = toLevelChangeProto(oldLevel, newLevel)
+	}
+
+	return resp, nil
+}
+
+// ListAdminAudit 分页查询管理员操作审计记录，管理员权限由 interceptors.AuthzPolicy 检查
+func (s *UserService) ListAdminAudit(ctx context.Context, req *v1.ListAdminAuditRequest) (*v1.ListAdminAuditResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.ListAdminAudit")
+	defer span.End()
+
+	_, err := meta.GetMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := po.AdminAuditFilter{
+		OperatorID: req.OperatorId,
+		UserID:     req.UserId,
+		Limit:      int(req.PageSize),
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditPageSize
+	} else if filter.Limit > maxAuditPageSize {
+		filter.Limit = maxAuditPageSize
+	}
+	if req.Cursor != "" {
+		filter.BeforeID, err = strconv.ParseInt(req.Cursor, 10, 64)
+		if err != nil || filter.BeforeID <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "无效的分页游标")
+		}
+	}
+
+	// 多取一条用于判断是否还有下一页
+	pageSize := filter.Limit
+	filter.Limit++
+	audits, err := s.pointRepo.ListAdminAudits(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "查询审计记录失败: %v", err)
+	}
+
+	resp := &v1.ListAdminAuditResponse{}
+	if len(audits) > pageSize {
+		audits = audits[:pageSize]
+		resp.NextCursor = strconv.FormatInt(audits[pageSize-1].ID, 10)
+	}
+	resp.Records = make([]*v1.AdminAuditRecord, 0, len(audits))
+	for _, audit := range audits {
+		resp.Records = append(resp.Records, toAdminAuditProto(audit))
+	}
+
+	return resp, nil
+}
+
+func toAdminAuditProto(audit *po.AdminAuditRecord) *v1.AdminAuditRecord {
+	return &v1.AdminAuditRecord{
+		Id:            audit.ID,
+		OperatorId:    audit.OperatorID,
+		UserId:        audit.UserID,
+		Points:        audit.Points,
+		Experience:    audit.Experience,
+		Reason:        audit.Reason,
+		Ticket:        audit.Ticket,
+		PointRecordId: audit.PointRecordID,
+		CreatedAt:     audit.CreatedAt.Unix(),
+	}
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/trancecho/mundo-points-system/pkg/testdb"
+	"github.com/trancecho/mundo-points-system/po"
+	v1 "github.com/trancecho/mundo-points-system/proto/point/v1"
+)
+
+func TestAdminAdjustPointsRejectsOversizedInput(t *testing.T) {
+	db := testdb.Open(t)
+	service := newTestService(db)
+	ctx := userContext(1, "admin")
+	if _, err := service.EnsureUser(userContext(2, "bob"), &v1.EnsureUserRequest{}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		reason string
+		ticket string
+		ok     bool
+	}{
+		{name: "原因过长", reason: strings.Repeat("补", maxAuditReasonLength+1), ticket: "T-1"},
+		{name: "工单号过长", reason: "补偿", ticket: strings.Repeat("T", maxAuditTicketLength+1)},
+		{name: "长度恰好为上限", reason: strings.Repeat("补", maxAuditReasonLength), ticket: strings.Repeat("T", maxAuditTicketLength), ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.AdminAdjustPoints(ctx, &v1.AdminAdjustPointsRequest{
+				UserId:      "2",
+				DeltaPoints: 5,
+				Reason:      tt.reason,
+				Ticket:      tt.ticket,
+			})
+			if err != nil {
+				t.Fatalf("AdminAdjustPoints: %v", err)
+			}
+			if tt.ok {
+				if !resp.Success {
+					t.Fatalf("AdminAdjustPoints = %+v", resp)
+				}
+				return
+			}
+			if resp.Success || resp.ErrorCode != v1.ErrorCode_INVALID_REQUEST {
+				t.Fatalf("AdminAdjustPoints = %+v, want INVALID_REQUEST", resp)
+			}
+		})
+	}
+
+	var audits int64
+	if err := db.Model(&po.AdminAuditRecord{}).Count(&audits).Error; err != nil {
+		t.Fatal(err)
+	}
+	if audits != 1 {
+		t.Fatalf("审计记录 %d 条, want 1", audits)
+	}
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/trancecho/mundo-points-system/po"
+)
+
+const (
+	// defaultExpirySweepInterval 未配置 point_expiry.sweep_interval 时清理过期积分的间隔
+	defaultExpirySweepInterval = time.Hour
+	// defaultExpiryWarnDays 未配置 point_expiry.warn_days 时提示即将过期积分的天数
+	defaultExpiryWarnDays = 30
+	// expireBatchSize 每批处理的用户数
+	expireBatchSize = 100
+)
+
+// PointLifetimeMonths 获得的积分多少个月后过期，未配置或为 0 表示不过期
+func PointLifetimeMonths() int {
+	return viper.GetInt("point_expiry.months")
+}
+
+// expiryWarnDays GetUserInfo 返回多少天内将要过期的积分
+func expiryWarnDays() int {
+	if viper.IsSet("point_expiry.warn_days") {
+		return viper.GetInt("point_expiry.warn_days")
+	}
+	return defaultExpiryWarnDays
+}
+
+// ExpirySweeper 定期作废过期的积分批次
+type ExpirySweeper struct {
+	pointRepo po.PointRepository
+}
+
+// NewExpirySweeper 创建过期积分清理任务
+func NewExpirySweeper(pointRepo po.PointRepository) *ExpirySweeper {
+	return &ExpirySweeper{
+		pointRepo: pointRepo,
+	}
+}
+
+// Run 启动后立即清理一次，之后定期清理直到 ctx 结束
+func (s *ExpirySweeper) Run(ctx context.Context) {
+	interval := viper.GetDuration("point_expiry.sweep_interval")
+	if interval <= 0 {
+		interval = defaultExpirySweepInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.Sweep(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep 分批作废当前已过期的积分批次
+func (s *ExpirySweeper) Sweep(ctx context.Context) {
+	now := time.Now()
+	total := 0
+	for {
+		n, err := s.pointRepo.ExpireLots(ctx, now, expireBatchSize)
+		total += n
+		if err != nil {
+			slog.ErrorContext(ctx, "清理过期积分失败", "users", total, "error", err)
+			return
+		}
+		if n < expireBatchSize {
+			break
+		}
+	}
+	if total > 0 {
+		slog.InfoContext(ctx, "清理过期积分完成", "users", total)
+	}
+}
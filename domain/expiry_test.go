@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/trancecho/mundo-points-system/po"
+)
+
+// fakeExpireRepo 按顺序返回每批处理的用户数，只实现 ExpireLots
+// batches 用完后设置了 err 时返回一整批和 err，没有停止时 Sweep 会一直循环
+type fakeExpireRepo struct {
+	po.PointRepository
+	batches []int
+	err     error
+	limits  []int
+}
+
+func (r *fakeExpireRepo) ExpireLots(ctx context.Context, now time.Time, limit int) (int, error) {
+	r.limits = append(r.limits, limit)
+	if len(r.batches) == 0 {
+		if r.err != nil {
+			return expireBatchSize, r.err
+		}
+		return 0, nil
+	}
+	n := r.batches[0]
+	r.batches = r.batches[1:]
+	return n, nil
+}
+
+func TestExpirySweeperSweep(t *testing.T) {
+	tests := []struct {
+		name      string
+		batches   []int
+		err       error
+		wantCalls int
+	}{
+		{name: "没有过期积分", batches: nil, wantCalls: 1},
+		{name: "不足一批", batches: []int{3}, wantCalls: 1},
+		{name: "多批直到不足一批", batches: []int{expireBatchSize, expireBatchSize, 7}, wantCalls: 3},
+		{name: "恰好整批时再查一次", batches: []int{expireBatchSize}, wantCalls: 2},
+		{name: "出错后停止", batches: []int{expireBatchSize}, err: errors.New("db down"), wantCalls: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeExpireRepo{batches: tt.batches, err: tt.err}
+			NewExpirySweeper(repo).Sweep(context.Background())
+			if len(repo.limits) != tt.wantCalls {
+				t.Fatalf("ExpireLots 调用次数 = %d, want %d", len(repo.limits), tt.wantCalls)
+			}
+			for _, limit := range repo.limits {
+				if limit != expireBatchSize {
+					t.Fatalf("每批用户数 = %d, want %d", limit, expireBatchSize)
+				}
+			}
+		})
+	}
+}
+
+func TestExpirySweeperRunStopsWithContext(t *testing.T) {
+	repo := &fakeExpireRepo{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		NewExpirySweeper(repo).Run(ctx)
+		close(done)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("ctx 结束后 Run 没有返回")
+	}
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/trancecho/mundo-points-system/pkg/meta"
+	"github.com/trancecho/mundo-points-system/pkg/tracing"
+	"github.com/trancecho/mundo-points-system/pkg/utils"
+	"github.com/trancecho/mundo-points-system/po"
+	v1 "github.com/trancecho/mundo-points-system/proto/point/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultLeaderboardLimit = 20
+	maxLeaderboardLimit     = 100
+)
+
+// GetLeaderboard 查询排行榜，同时返回当前用户的名次
+func (s *UserService) GetLeaderboard(ctx context.Context, req *v1.GetLeaderboardRequest) (*v1.GetLeaderboardResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetLeaderboard")
+	defer span.End()
+
+	_, err := meta.GetMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userClaims, ok := ctx.Value("claims").(*utils.Claims)
+	if !ok {
+		return nil, status.Errorf(400, "failed to get user claims from context")
+	}
+
+	query := po.LeaderboardQuery{
+		Metric: po.LeaderboardMetric(req.Metric),
+		Since:  periodStart(req.Period, time.Now()),
+		Limit:  int(req.Limit),
+		Offset: int(req.Offset),
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultLeaderboardLimit
+	} else if query.Limit > maxLeaderboardLimit {
+		query.Limit = maxLeaderboardLimit
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+
+	entries, err := s.statRepo.GetLeaderboard(ctx, query)
+	if errors.Is(err, po.ErrUnsupportedLeaderboard) {
+		return nil, status.Errorf(codes.InvalidArgument, "不支持的排行榜指标，周期榜只支持积分和经验")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "获取排行榜失败: %v", err)
+	}
+	myEntry, err := s.statRepo.GetLeaderboardRank(ctx, query, strconv.FormatInt(userClaims.UserID, 10))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "获取用户名次失败: %v", err)
+	}
+
+	// 一次查询补全榜上用户的用户名和等级
+	userIDs := make([]string, 0, len(entries)+1)
+	for _, entry := range entries {
+		userIDs = append(userIDs, entry.UserID)
+	}
+	if myEntry != nil {
+		userIDs = append(userIDs, myEntry.UserID)
+	}
+	users, err := s.userRepo.GetUsersByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "获取用户信息失败: %v", err)
+	}
+	userMap := make(map[string]*po.UserInfo, len(users))
+	for _, user := range users {
+		userMap[strconv.FormatInt(user.UserID, 10)] = user
+	}
+
+	resp := &v1.GetLeaderboardResponse{
+		Entries: make([]*v1.LeaderboardEntry, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, toLeaderboardEntryProto(entry, userMap[entry.UserID]))
+	}
+	if myEntry != nil {
+		resp.MyEntry = toLeaderboardEntryProto(myEntry, userMap[myEntry.UserID])
+	}
+	return resp, nil
+}
+
+// periodStart 周期榜的起始时间，总榜返回零值
+func periodStart(period v1.LeaderboardPeriod, now time.Time) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch period {
+	case v1.LeaderboardPeriod_PERIOD_WEEK:
+		// 以周一为一周的第一天
+		offset := (int(today.Weekday()) + 6) % 7
+		return today.AddDate(0, 0, -offset)
+	case v1.LeaderboardPeriod_PERIOD_MONTH:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	default:
+		return time.Time{}
+	}
+}
+
+func toLeaderboardEntryProto(entry *po.LeaderboardEntry, user *po.UserInfo) *v1.LeaderboardEntry {
+	result := &v1.LeaderboardEntry{
+		Rank:   entry.Rank,
+		UserId: entry.UserID,
+		Value:  entry.Value,
+	}
+	if user != nil {
+		result.Username = user.Username
+		result.Level = int32(user.Level)
+	}
+	return result
+}
@@ -0,0 +1,197 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+
+	"github.com/spf13/viper"
+	"github.com/trancecho/mundo-points-system/pkg/meta"
+	"github.com/trancecho/mundo-points-system/pkg/tracing"
+	"github.com/trancecho/mundo-points-system/po"
+	v1 "github.com/trancecho/mundo-points-system/proto/point/v1"
+)
+
+// DefaultLevelCurve 未配置时使用的默认等级曲线
+func DefaultLevelCurve() po.LevelCurve {
+	return po.LevelCurve{
+		{Level: 1, Name: "新手", MinExperience: 0},
+		{Level: 2, Name: "入门", MinExperience: 100},
+		{Level: 3, Name: "初级", MinExperience: 500},
+		{Level: 4, Name: "中级", MinExperience: 1000},
+		{Level: 5, Name: "高级", MinExperience: 2000},
+		{Level: 6, Name: "资深", MinExperience: 5000},
+		{Level: 7, Name: "专家", MinExperience: 10000},
+		{Level: 8, Name: "大师", MinExperience: 18000},
+		{Level: 9, Name: "宗师", MinExperience: 30000},
+		{Level: 10, Name: "传奇", MinExperience: 50000},
+	}
+}
+
+// LoadLevelCurve 从配置项 levels 读取等级曲线，未配置时使用默认曲线
+func LoadLevelCurve() (po.LevelCurve, error) {
+	if !viper.IsSet("levels") {
+		return DefaultLevelCurve(), nil
+	}
+	var curve po.LevelCurve
+	if err := viper.UnmarshalKey("levels", &curve); err != nil {
+		return nil, err
+	}
+	if err := validateLevelCurve(curve); err != nil {
+		return nil, err
+	}
+	return curve, nil
+}
+
+// validateLevelCurve 检查等级和经验门槛都严格递增，且最低等级从 0 经验开始
+func validateLevelCurve(curve po.LevelCurve) error {
+	if len(curve) == 0 {
+		return errors.New("等级曲线不能为空")
+	}
+	if curve[0].MinExperience != 0 {
+		return errors.New("最低等级的经验门槛必须为0")
+	}
+	for i := 1; i < len(curve); i++ {
+		if curve[i].Level <= curve[i-1].Level {
+			return fmt.Errorf("等级 %d 必须大于上一级 %d", curve[i].Level, curve[i-1].Level)
+		}
+		if curve[i].MinExperience <= curve[i-1].MinExperience {
+			return fmt.Errorf("等级 %d 的经验门槛必须大于上一级", curve[i].Level)
+		}
+	}
+	return nil
+}
+
+// LevelEngine 等级曲线，支持运行时替换，实现 po.LevelProvider
+type LevelEngine struct {
+	curve atomic.Pointer[po.LevelCurve]
+}
+
+// NewLevelEngine 创建等级曲线
+func NewLevelEngine(curve po.LevelCurve) *LevelEngine {
+	engine := &LevelEngine{}
+	engine.Store(curve)
+	return engine
+}
+
+// Store 替换当前生效的等级曲线
+func (e *LevelEngine) Store(curve po.LevelCurve) {
+	curve = append(po.LevelCurve(nil), curve...)
+	e.curve.Store(&curve)
+}
+
+// Reload 从配置重新加载等级曲线，返回曲线是否发生变化，加载失败时保留原曲线
+func (e *LevelEngine) Reload() (bool, error) {
+	curve, err := LoadLevelCurve()
+	if err != nil {
+		return false, err
+	}
+	changed := !sameThresholds(e.Curve(), curve)
+	e.Store(curve)
+	return changed, nil
+}
+
+// Curve 返回当前生效的等级曲线
+func (e *LevelEngine) Curve() po.LevelCurve {
+	return *e.curve.Load()
+}
+
+// sameThresholds 判断两条曲线的等级门槛是否一致，名称和权益变化不影响用户等级
+func sameThresholds(a, b po.LevelCurve) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Level != b[i].Level || a[i].MinExperience != b[i].MinExperience {
+			return false
+		}
+	}
+	return true
+}
+
+// ListLevels 获取等级定义列表
+func (s *UserService) ListLevels(ctx context.Context, req *v1.ListLevelsRequest) (*v1.ListLevelsResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.ListLevels")
+	defer span.End()
+
+	_, err := meta.GetMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	curve := s.levels.Curve()
+	resp := &v1.ListLevelsResponse{
+		Levels: make([]*v1.LevelDefinition, 0, len(curve)),
+	}
+	for _, level := range curve {
+		resp.Levels = append(resp.Levels, &v1.LevelDefinition{
+			Level:         int32(level.Level),
+			Name:          level.Name,
+			MinExperience: level.MinExperience,
+			Perks:         level.Perks,
+		})
+	}
+
+	return resp, nil
+}
+
+// toLevelChangeProto 等级发生变化时返回等级变化信息，否则返回 nil
+func toLevelChangeProto(oldLevel int, newLevel int) *v1.LevelChange {
+	if oldLevel == newLevel {
+		return nil
+	}
+	return &v1.LevelChange{
+		OldLevel: int32(oldLevel),
+		NewLevel: int32(newLevel),
+	}
+}
+
+// LevelRecalculator 在后台按当前等级曲线重算所有用户等级，同一时间只有一次重算在执行
+type LevelRecalculator struct {
+	userRepo po.UserRepository
+	mu       sync.Mutex
+	running  bool
+	pending  bool // 重算期间又被触发，本次结束后需要再算一次
+}
+
+// NewLevelRecalculator 创建等级重算任务
+func NewLevelRecalculator(userRepo po.UserRepository) *LevelRecalculator {
+	return &LevelRecalculator{
+		userRepo: userRepo,
+	}
+}
+
+// Trigger 触发一次重算，已有重算在执行时只标记待重算，不会并发执行
+func (r *LevelRecalculator) Trigger() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running {
+		r.pending = true
+		return
+	}
+	r.running = true
+	go r.run()
+}
+
+func (r *LevelRecalculator) run() {
+	for {
+		updated, err := r.userRepo.RecalculateLevels(context.Background())
+		if err != nil {
+			slog.Error("重算用户等级失败", "error", err)
+		} else {
+			slog.Info("重算用户等级完成", "updated", updated)
+		}
+
+		r.mu.Lock()
+		if !r.pending {
+			r.running = false
+			r.mu.Unlock()
+			return
+		}
+		r.pending = false
+		r.mu.Unlock()
+	}
+}
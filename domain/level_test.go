@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/trancecho/mundo-points-system/po"
+)
+
+// fakeRecalcRepo 记录 RecalculateLevels 的调用次数和最大并发数，每次调用等待 release
+type fakeRecalcRepo struct {
+	po.UserRepository
+	release   chan struct{}
+	mu        sync.Mutex
+	calls     int
+	active    int
+	maxActive int
+}
+
+func (r *fakeRecalcRepo) RecalculateLevels(ctx context.Context) (int64, error) {
+	r.mu.Lock()
+	r.calls++
+	r.active++
+	r.maxActive = max(r.maxActive, r.active)
+	r.mu.Unlock()
+
+	<-r.release
+
+	r.mu.Lock()
+	r.active--
+	r.mu.Unlock()
+	return 0, nil
+}
+
+func (r *fakeRecalcRepo) snapshot() (calls int, maxActive int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.calls, r.maxActive
+}
+
+func TestLevelRecalculatorCoalescesTriggers(t *testing.T) {
+	repo := &fakeRecalcRepo{release: make(chan struct{})}
+	recalculator := NewLevelRecalculator(repo)
+
+	recalculator.Trigger()
+	waitFor(t, func() bool { calls, _ := repo.snapshot(); return calls == 1 })
+	// 重算期间多次触发只会在本次结束后再算一次
+	for i := 0; i < 5; i++ {
+		recalculator.Trigger()
+	}
+	repo.release <- struct{}{}
+	waitFor(t, func() bool { calls, _ := repo.snapshot(); return calls == 2 })
+	repo.release <- struct{}{}
+
+	waitFor(t, func() bool {
+		recalculator.mu.Lock()
+		defer recalculator.mu.Unlock()
+		return !recalculator.running
+	})
+	calls, maxActive := repo.snapshot()
+	if calls != 2 || maxActive != 1 {
+		t.Fatalf("调用次数 = %d, 最大并发 = %d, want 2, 1", calls, maxActive)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("等待超时")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/trancecho/mundo-points-system/interceptors"
+	"github.com/trancecho/mundo-points-system/pkg/testdb"
+	"github.com/trancecho/mundo-points-system/pkg/utils"
+	"github.com/trancecho/mundo-points-system/po"
+	v1 "github.com/trancecho/mundo-points-system/proto/point/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"gorm.io/gorm"
+)
+
+// startTestServer 在内存连接上启动带 JWT 和权限拦截器的 gRPC 服务，返回客户端
+// opts 为额外的服务端选项，如链路追踪的 stats handler
+func startTestServer(t *testing.T, db *gorm.DB, opts ...grpc.ServerOption) v1.UserServiceClient {
+	t.Helper()
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	secret := utils.MundoSecret
+	utils.MundoSecret = []byte("e2e-secretmundo")
+	t.Cleanup(func() { utils.MundoSecret = secret })
+
+	policies, err := interceptors.LoadPolicies()
+	if err != nil {
+		t.Fatalf("加载权限策略失败: %v", err)
+	}
+	server := grpc.NewServer(append(opts, grpc.ChainUnaryInterceptor(
+		interceptors.JWTInterceptor(),
+		interceptors.NewAuthzPolicy(policies).Interceptor(),
+	))...)
+	v1.RegisterUserServiceServer(server, newTestService(db))
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("连接测试服务失败: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return v1.NewUserServiceClient(conn)
+}
+
+// withToken 为当前用户签发 token 并放入请求 metadata
+func withToken(t *testing.T, userID int64, username string) context.Context {
+	t.Helper()
+	token, err := utils.GenerateToken(userID, username, "", "mundo")
+	if err != nil {
+		t.Fatalf("签发token失败: %v", err)
+	}
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestProcessLikeEndToEnd(t *testing.T) {
+	db := testdb.Open(t)
+	client := startTestServer(t, db)
+	for _, user := range []*po.UserInfo{
+		{UserID: 1, Username: "liker", Level: 1},
+		{UserID: 2, Username: "author", Level: 1},
+	} {
+		if err := db.Create(user).Error; err != nil {
+			t.Fatalf("创建用户失败: %v", err)
+		}
+	}
+	req := &v1.LikeRequest{PostId: "post-1", TargetUserId: "2"}
+
+	// 没有 token 的请求在拦截器中被拒绝
+	if _, err := client.ProcessLike(context.Background(), req); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("未登录点赞应返回 Unauthenticated，得到 %v", err)
+	}
+
+	resp, err := client.ProcessLike(withToken(t, 1, "liker"), req)
+	if err != nil || !resp.Success {
+		t.Fatalf("ProcessLike = %+v, %v", resp, err)
+	}
+
+	var record po.PointRecord
+	if err := db.Where("user_id = ? AND reason = ?", "2", po.ReasonLiked).First(&record).Error; err != nil {
+		t.Fatalf("查询被点赞记录失败: %v", err)
+	}
+	if record.RelatedUserID != "1" || record.RefID != "post-1" {
+		t.Fatalf("被点赞记录 related_user_id=%q ref_id=%q, want 1 和 post-1", record.RelatedUserID, record.RefID)
+	}
+	if record.Points != LikePoints || record.Experience != LikeExperience {
+		t.Fatalf("被点赞记录 points=%d experience=%d", record.Points, record.Experience)
+	}
+	var author po.UserInfo
+	if err := db.Where("user_id = ?", 2).First(&author).Error; err != nil {
+		t.Fatalf("查询被点赞用户失败: %v", err)
+	}
+	if author.Experience != LikeExperience || author.Points != LikePoints {
+		t.Fatalf("被点赞用户 points=%d experience=%d", author.Points, author.Experience)
+	}
+
+	// 重复点赞不再加分
+	if _, err := client.ProcessLike(withToken(t, 1, "liker"), req); status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("重复点赞应返回 AlreadyExists，得到 %v", err)
+	}
+}
@@ -2,12 +2,15 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"strconv"
 	"time"
 
 	"github.com/trancecho/mundo-points-system/pkg/meta"
+	"github.com/trancecho/mundo-points-system/pkg/metrics"
+	"github.com/trancecho/mundo-points-system/pkg/tracing"
 	"github.com/trancecho/mundo-points-system/pkg/utils"
 	"github.com/trancecho/mundo-points-system/po"
 	v1 "github.com/trancecho/mundo-points-system/proto/point/v1"
@@ -15,24 +18,36 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-const LikePoints = int64(1)
+const (
+	LikePoints     = int64(1) // 被点赞获得的积分
+	LikeExperience = int64(1) // 被点赞获得的经验
+)
 
 type UserService struct {
 	v1.UnimplementedUserServiceServer
-	userRepo  po.UserRepository
-	pointRepo po.PointRepository
-	statRepo  po.StatisticsRepository
+	userRepo    po.UserRepository
+	pointRepo   po.PointRepository
+	statRepo    po.StatisticsRepository
+	shopRepo    po.ShopRepository
+	signRewards *SignRewardEngine
+	levels      *LevelEngine
 }
 
-func NewUserService(userRepo po.UserRepository, pointRepo po.PointRepository, statRepo po.StatisticsRepository) *UserService {
+func NewUserService(userRepo po.UserRepository, pointRepo po.PointRepository, statRepo po.StatisticsRepository, shopRepo po.ShopRepository, signRewards *SignRewardEngine, levels *LevelEngine) *UserService {
 	return &UserService{
-		userRepo:  userRepo,
-		pointRepo: pointRepo,
-		statRepo:  statRepo,
+		userRepo:    userRepo,
+		pointRepo:   pointRepo,
+		statRepo:    statRepo,
+		shopRepo:    shopRepo,
+		signRewards: signRewards,
+		levels:      levels,
 	}
 }
 
-func (s *UserService) UpdatePointsAndExperience(ctx context.Context, req *v1.UpdatePointsRequest) (*v1.CommonResponse, error) {
+func (s *UserService) UpdatePointsAndExperience(ctx context.Context, req *v1.UpdatePointsRequest) (*v1.UpdatePointsResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdatePointsAndExperience")
+	defer span.End()
+
 	_, err := meta.GetMetadata(ctx)
 	if err != nil {
 		return nil, err
@@ -41,76 +56,154 @@ func (s *UserService) UpdatePointsAndExperience(ctx context.Context, req *v1.Upd
 	if !ok {
 		return nil, status.Errorf(400, "failed to get user claims from context")
 	}
-	//如果扣除积分，需要查看用户积分是否足够
-	if req.DeltaPoints < 0 {
-		user, err := s.userRepo.GetUserByID(ctx, strconv.FormatInt(userClaims.UserID, 10))
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "获取用户ID失败: %v", err)
-		}
-
-		if user.Points < -req.DeltaPoints {
-			return &v1.CommonResponse{
-				Success:   false,
-				Message:   "积分不足",
-				ErrorCode: v1.ErrorCode_POINTS_INSUFFICIENT,
-			}, nil
-		}
+	// 只有管理员和服务账号可以调用（见 interceptors.AuthzPolicy），指定 user_id 时为该用户变更积分
+	userID := strconv.FormatInt(userClaims.UserID, 10)
+	if req.UserId != "" {
+		userID = req.UserId
+	}
+	//每日获取额度由仓库在同一事务内检查，超出部分不发放
+	dailyLimit, err := dailyQuota(req.Reason)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "加载积分额度失败: %v", err)
+	}
+	//更新积分和经验，扣除积分时由仓库在同一事务内检查余额
+	record, err := s.pointRepo.AddPointsAndExperience(ctx, po.PointChange{
+		UserID:         userID,
+		Points:         req.DeltaPoints,
+		Experience:     req.DeltaExperience,
+		Reason:         req.Reason,
+		IdempotencyKey: req.IdempotencyKey,
+		DailyLimit:     dailyLimit,
+	})
+	if errors.Is(err, po.ErrEarnLimitExceeded) {
+		return &v1.UpdatePointsResponse{
+			Success:       false,
+			Message:       "已达到今日积分获取上限",
+			ErrorCode:     v1.ErrorCode_LIMIT_EXCEEDED,
+			GrantedPoints: 0,
+		}, nil
+	}
+	if errors.Is(err, po.ErrPointsInsufficient) {
+		metrics.ObservePointsInsufficient(v1.UserService_UpdatePointsAndExperience_FullMethodName)
+		return &v1.UpdatePointsResponse{
+			Success:   false,
+			Message:   "积分不足",
+			ErrorCode: v1.ErrorCode_POINTS_INSUFFICIENT,
+		}, nil
+	}
+	if errors.Is(err, po.ErrIdempotencyKeyConflict) {
+		return &v1.UpdatePointsResponse{
+			Success:   false,
+			Message:   "幂等键已被其他请求使用",
+			ErrorCode: v1.ErrorCode_INVALID_REQUEST,
+		}, nil
+	}
+	if errors.Is(err, po.ErrUserNotFound) {
+		return nil, status.Errorf(codes.NotFound, "用户不存在")
 	}
-	//更新积分和经验
-	err = s.pointRepo.AddPointsAndExperience(ctx, strconv.FormatInt(userClaims.UserID, 10), req.DeltaPoints, req.DeltaExperience, req.Reason)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "更新积分和经验失败: %v", err)
 	}
+	//重放的请求返回首次写入的记录
+	resp := &v1.UpdatePointsResponse{
+		Success:           true,
+		Message:           "更新积分和经验成功",
+		ErrorCode:         v1.ErrorCode_NONE_ERROR,
+		GrantedPoints:     record.Points,
+		GrantedExperience: record.Experience,
+		RecordId:          record.ID,
+	}
+	if record.Points < req.DeltaPoints {
+		resp.Message = "更新积分和经验成功，部分积分超出今日获取上限"
+	}
 	//如果有经验变更，则可能需要更新等级
 	if req.DeltaExperience != 0 {
-		err = s.userRepo.UpdateLevelByExperience(ctx, strconv.FormatInt(userClaims.UserID, 10))
+		oldLevel, newLevel, err := s.userRepo.UpdateLevelByExperience(ctx, userID)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "更新等级失败: %v", err)
 		}
+		resp.LevelChange = toLevelChangeProto(oldLevel, newLevel)
 	}
 
-	return &v1.CommonResponse{
-		Success:   true,
-		Message:   "更新积分和经验成功",
-		ErrorCode: v1.ErrorCode_NONE_ERROR,
-	}, nil
+	return resp, nil
 }
 
 func (s *UserService) GetUserInfo(ctx context.Context, req *v1.GetUserInfoRequest) (*v1.UserInfo, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserInfo")
+	defer span.End()
+
 	_, err := meta.GetMetadata(ctx)
 	if err != nil {
 		return nil, err
 	}
 	user, err := s.userRepo.GetUserByID(ctx, req.UserId)
+	if errors.Is(err, po.ErrUserNotFound) {
+		return nil, status.Errorf(codes.NotFound, "用户不存在")
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "获取用户信息失败: %v", err)
 	}
-	return &v1.UserInfo{
-		UserId:             req.UserId,
-		Username:           user.Username,
-		Points:             user.Points,
-		Experience:         user.Experience,
-		Level:              int32(user.Level),
-		ContinuousSignDays: user.ContinuousSignDay,
-		TotalSignDays:      user.TotalSignDay,
-		ActivityScore:      user.ActivityScore,
-	}, nil
+	info := toUserInfoProto(user)
+	// 提示即将过期的积分
+	expiringPoints, expireAt, err := s.pointRepo.SumExpiringPoints(ctx, req.UserId, time.Now().AddDate(0, 0, expiryWarnDays()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "获取即将过期积分失败: %v", err)
+	}
+	info.ExpiringPoints = expiringPoints
+	if expireAt != nil {
+		info.NextExpireTime = expireAt.Unix()
+	}
+	return info, nil
 }
 
 func (s *UserService) ProcessLike(ctx context.Context, req *v1.LikeRequest) (*v1.CommonResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.ProcessLike")
+	defer span.End()
+
 	_, err := meta.GetMetadata(ctx)
 	if err != nil {
 		return nil, err
 	}
-	userClaims, ok := ctx.Value("userClaims").(*po.UserInfo)
+	userClaims, ok := ctx.Value("claims").(*utils.Claims)
 	if !ok {
 		return nil, status.Errorf(400, "failed to get user claims from context")
 	}
+	userID := strconv.FormatInt(userClaims.UserID, 10)
+	if req.PostId == "" || req.TargetUserId == "" {
+		return &v1.CommonResponse{
+			Success:   false,
+			Message:   "帖子和被点赞用户不能为空",
+			ErrorCode: v1.ErrorCode_INVALID_REQUEST,
+		}, nil
+	}
+	if req.TargetUserId == userID {
+		return &v1.CommonResponse{
+			Success:   false,
+			Message:   "不能给自己点赞",
+			ErrorCode: v1.ErrorCode_INVALID_REQUEST,
+		}, nil
+	}
+	//被点赞积分受每日额度限制，超出后点赞仍然成功但不再加分
+	dailyLimit, err := dailyQuota(po.ReasonLiked)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "加载积分额度失败: %v", err)
+	}
 	//记录点赞信息
-	err = s.pointRepo.RecordLike(ctx, strconv.FormatInt(userClaims.UserID, 10), req.PostId, req.TargetUserId)
+	err = s.pointRepo.RecordLike(ctx, userID, req.PostId, req.TargetUserId, LikePoints, LikeExperience, dailyLimit)
+	if errors.Is(err, po.ErrUserNotFound) {
+		return nil, status.Errorf(codes.NotFound, "被点赞用户不存在")
+	}
+	if errors.Is(err, po.ErrAlreadyLiked) {
+		return nil, status.Errorf(codes.AlreadyExists, "已经点过赞")
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "点赞失败: %v", err)
 	}
+	metrics.ObserveLike("like")
+	//被点赞者获得经验后可能升级
+	if _, _, err = s.userRepo.UpdateLevelByExperience(ctx, req.TargetUserId); err != nil {
+		slog.ErrorContext(ctx, "更新被点赞者等级失败", "user_id", req.TargetUserId, "error", err)
+	}
 
 	return &v1.CommonResponse{
 		Success:   true,
@@ -119,21 +212,55 @@ func (s *UserService) ProcessLike(ctx context.Context, req *v1.LikeRequest) (*v1
 	}, nil
 }
 
-// GetAdminStats 实现获取管理员统计数据功能
-func (s *UserService) GetAdminStats(ctx context.Context, req *v1.GetUserInfoRequest) (*v1.AdminStats, error) {
+// UnprocessLike 取消点赞
+func (s *UserService) UnprocessLike(ctx context.Context, req *v1.LikeRequest) (*v1.CommonResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UnprocessLike")
+	defer span.End()
+
 	_, err := meta.GetMetadata(ctx)
 	if err != nil {
 		return nil, err
 	}
-
 	userClaims, ok := ctx.Value("claims").(*utils.Claims)
 	if !ok {
 		return nil, status.Errorf(400, "failed to get user claims from context")
 	}
-	//验证管理员权限
-	if userClaims.Role != "admin" {
-		return nil, status.Errorf(codes.PermissionDenied, "用户无权限")
+	//取消点赞并收回积分
+	targetUserID, err := s.pointRepo.UnrecordLike(ctx, strconv.FormatInt(userClaims.UserID, 10), req.PostId)
+	if errors.Is(err, po.ErrLikeNotFound) {
+		return &v1.CommonResponse{
+			Success:   false,
+			Message:   "没有点过赞",
+			ErrorCode: v1.ErrorCode_INVALID_REQUEST,
+		}, nil
 	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "取消点赞失败: %v", err)
+	}
+	metrics.ObserveLike("unlike")
+	//被点赞者扣除经验后可能降级
+	if _, _, err = s.userRepo.UpdateLevelByExperience(ctx, targetUserID); err != nil {
+		slog.ErrorContext(ctx, "更新被点赞者等级失败", "user_id", targetUserID, "error", err)
+	}
+
+	return &v1.CommonResponse{
+		Success:   true,
+		Message:   "取消点赞成功",
+		ErrorCode: v1.ErrorCode_NONE_ERROR,
+	}, nil
+}
+
+// GetAdminStats 实现获取管理员统计数据功能
+func (s *UserService) GetAdminStats(ctx context.Context, req *v1.GetUserInfoRequest) (*v1.AdminStats, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetAdminStats")
+	defer span.End()
+
+	_, err := meta.GetMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 管理员权限由 interceptors.AuthzPolicy 检查
 	// 获取等级分布
 	levelDistribution, err := s.statRepo.GetLevelDistribution(ctx)
 	if err != nil {
@@ -169,13 +296,30 @@ func (s *UserService) GetAdminStats(ctx context.Context, req *v1.GetUserInfoRequ
 	return stats, nil
 }
 
-func (s *UserService) Sign(ctx context.Context, req *v1.SignRequest) (*v1.CommonResponse, error) {
+// Sign 用户签到，返回结构化的签到奖励
+// Message 中仍保留文字描述，兼容按 CommonResponse 解析响应的旧客户端
+func (s *UserService) Sign(ctx context.Context, req *v1.SignRequest) (*v1.SignResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Sign")
+	defer span.End()
+
 	_, err := meta.GetMetadata(ctx)
 	if err != nil {
 		return nil, err
 	}
-	//获取用户信息
-	user, err := s.userRepo.GetUserByID(ctx, req.UserId)
+	userClaims, ok := ctx.Value("claims").(*utils.Claims)
+	if !ok {
+		return nil, status.Errorf(400, "failed to get user claims from context")
+	}
+	//只能为当前用户签到，服务账号通过 x-on-behalf-of 指定用户
+	userID := strconv.FormatInt(userClaims.UserID, 10)
+	if req.UserId != "" && req.UserId != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "只能为当前用户签到")
+	}
+	//获取用户信息，用户需要先通过 EnsureUser 创建
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if errors.Is(err, po.ErrUserNotFound) {
+		return nil, status.Errorf(codes.NotFound, "用户不存在")
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "获取用户信息失败: %v", err)
 	}
@@ -188,10 +332,12 @@ func (s *UserService) Sign(ctx context.Context, req *v1.SignRequest) (*v1.Common
 
 		// 如果在同一天已经签到
 		if lastSignYear == currentYear && lastSignMonth == currentMonth && lastSignDay == currentDay {
-			return &v1.CommonResponse{
-				Success:   false,
-				Message:   "今日已签到",
-				ErrorCode: v1.ErrorCode_INVALID_REQUEST,
+			return &v1.SignResponse{
+				Success:            false,
+				Message:            "今日已签到",
+				ErrorCode:          v1.ErrorCode_INVALID_REQUEST,
+				ContinuousSignDays: user.ContinuousSignDay,
+				Level:              int32(user.Level),
 			}, nil
 		}
 		//检查是否连续签到
@@ -202,57 +348,59 @@ func (s *UserService) Sign(ctx context.Context, req *v1.SignRequest) (*v1.Common
 			user.ContinuousSignDay = 0 // 重置连续签到次数
 		}
 	}
-	// 计算签到奖励
-	pointsReward := int64(50)  // 基础积分奖励
-	expReward := int64(10)     // 基础经验奖励
-	activityReward := int64(5) // 基础活跃度奖励
-
-	// 连续签到奖励加成
+	// 连续签到天数
 	continuousDay := user.ContinuousSignDay + 1
 
-	// 根据活跃度计算加成
-	activityBonus := 0.0
-
-	// 直接使用 user.ActivityScore
-	if user.ActivityScore >= 10000 {
-		activityBonus = 0.5
-	} else if user.ActivityScore >= 5000 {
-		activityBonus = 0.3
-	} else if user.ActivityScore >= 2000 {
-		activityBonus = 0.2
-	} else if user.ActivityScore >= 500 {
-		activityBonus = 0.1
-	}
-
-	// 应用活跃度加成
-	pointsReward = pointsReward + int64(float64(pointsReward)*activityBonus)
-	expReward = expReward + int64(float64(expReward)*activityBonus)
+	// 按当前生效的规则计算签到奖励
+	reward := s.signRewards.Calculate(user.ActivityScore, continuousDay)
+	pointsReward := reward.Points
+	expReward := reward.Experience
+	activityReward := reward.Activity
 
 	// 更新用户签到信息
 	totalDay := user.TotalSignDay + 1
 
-	err = s.userRepo.UpdateSignStatus(ctx, req.UserId, true, continuousDay, totalDay)
+	err = s.userRepo.UpdateSignStatus(ctx, userID, true, continuousDay, totalDay)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "更新签到状态失败: %v", err)
 	}
 
 	// 添加积分和经验
-	err = s.pointRepo.AddPointsAndExperience(ctx, req.UserId, pointsReward, expReward, "每日签到")
+	_, err = s.pointRepo.AddPointsAndExperience(ctx, po.PointChange{
+		UserID:     userID,
+		Points:     pointsReward,
+		Experience: expReward,
+		Reason:     po.ReasonSign,
+	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "添加积分和经验失败: %v", err)
 	}
 
 	// 直接更新用户活跃度
-	err = s.userRepo.UpdateActivityScore(ctx, req.UserId, activityReward)
+	err = s.userRepo.UpdateActivityScore(ctx, userID, activityReward)
 	if err != nil {
 		// 只记录日志，不影响签到主流程
-		log.Printf("更新用户活跃度失败: %v", err)
+		slog.ErrorContext(ctx, "更新用户活跃度失败", "user_id", userID, "error", err)
+	}
+
+	// 签到获得经验后可能升级
+	oldLevel, newLevel, err := s.userRepo.UpdateLevelByExperience(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "更新等级失败: %v", err)
 	}
 
+	metrics.ObserveSignIn()
+
 	// 返回签到成功及奖励信息
-	return &v1.CommonResponse{
-		Success:   true,
-		Message:   fmt.Sprintf("签到成功，获得积分: %d, 经验: %d, 活跃度: %d", pointsReward, expReward, activityReward),
-		ErrorCode: v1.ErrorCode_NONE_ERROR,
+	return &v1.SignResponse{
+		Success:            true,
+		Message:            fmt.Sprintf("签到成功，获得积分: %d, 经验: %d, 活跃度: %d", pointsReward, expReward, activityReward),
+		ErrorCode:          v1.ErrorCode_NONE_ERROR,
+		Points:             pointsReward,
+		Experience:         expReward,
+		ContinuousSignDays: continuousDay,
+		Activity:           activityReward,
+		Level:              int32(newLevel),
+		LevelChange:        toLevelChangeProto(oldLevel, newLevel),
 	}, nil
 }
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+	"github.com/trancecho/mundo-points-system/po"
+)
+
+// QuotaRule 按积分来源限制每个用户每天最多获得的积分
+// 只限制积分，不限制经验；只用于 UpdatePointsAndExperience（按 reason 匹配）和被点赞，签到奖励由 sign_reward 决定，不受额度限制
+type QuotaRule struct {
+	Reason     string `mapstructure:"reason"`
+	DailyLimit int64  `mapstructure:"daily_limit"`
+}
+
+// defaultQuotaRules 未配置 point_quota 时使用的默认额度
+func defaultQuotaRules() []QuotaRule {
+	return []QuotaRule{
+		{Reason: po.ReasonLiked, DailyLimit: 20},
+	}
+}
+
+// loadQuotaRules 从配置项 point_quota 读取额度规则，每次调用都读取，配置变更后立即生效
+func loadQuotaRules() ([]QuotaRule, error) {
+	if !viper.IsSet("point_quota") {
+		return defaultQuotaRules(), nil
+	}
+	var rules []QuotaRule
+	if err := viper.UnmarshalKey("point_quota", &rules); err != nil {
+		return nil, fmt.Errorf("解析 point_quota 配置失败: %w", err)
+	}
+	return rules, nil
+}
+
+// dailyQuota 返回来源 reason 每天最多获得的积分，0 表示不限
+// 额度在仓库事务内锁定用户后检查，多实例部署时同样有效
+func dailyQuota(reason string) (int64, error) {
+	rules, err := loadQuotaRules()
+	if err != nil {
+		return 0, err
+	}
+	for _, rule := range rules {
+		if rule.Reason == reason {
+			return rule.DailyLimit, nil
+		}
+	}
+	return 0, nil
+}
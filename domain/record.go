@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/trancecho/mundo-points-system/pkg/meta"
+	"github.com/trancecho/mundo-points-system/pkg/metrics"
+	"github.com/trancecho/mundo-points-system/pkg/tracing"
+	"github.com/trancecho/mundo-points-system/pkg/utils"
+	"github.com/trancecho/mundo-points-system/po"
+	v1 "github.com/trancecho/mundo-points-system/proto/point/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultRecordPageSize = 20
+	maxRecordPageSize     = 100
+)
+
+// ListPointRecords 分页查询积分变更记录
+func (s *UserService) ListPointRecords(ctx context.Context, req *v1.ListPointRecordsRequest) (*v1.ListPointRecordsResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.ListPointRecords")
+	defer span.End()
+
+	_, err := meta.GetMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userClaims, ok := ctx.Value("claims").(*utils.Claims)
+	if !ok {
+		return nil, status.Errorf(400, "failed to get user claims from context")
+	}
+
+	// 默认查询自己的记录，查询他人记录的权限由 interceptors.AuthzPolicy 检查
+	userID := strconv.FormatInt(userClaims.UserID, 10)
+	if req.UserId != "" {
+		userID = req.UserId
+	}
+
+	filter := po.PointRecordFilter{
+		UserID:    userID,
+		Limit:     int(req.PageSize),
+		Reason:    req.Reason,
+		Direction: po.PointDirection(req.Direction),
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultRecordPageSize
+	} else if filter.Limit > maxRecordPageSize {
+		filter.Limit = maxRecordPageSize
+	}
+	if req.Cursor != "" {
+		filter.BeforeID, err = strconv.ParseInt(req.Cursor, 10, 64)
+		if err != nil || filter.BeforeID <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "无效的分页游标")
+		}
+	}
+	if req.StartTime > 0 {
+		filter.StartTime = time.Unix(req.StartTime, 0)
+	}
+	if req.EndTime > 0 {
+		filter.EndTime = time.Unix(req.EndTime, 0)
+	}
+
+	// 多取一条用于判断是否还有下一页
+	pageSize := filter.Limit
+	filter.Limit++
+	records, err := s.pointRepo.ListPointRecords(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "查询积分记录失败: %v", err)
+	}
+
+	resp := &v1.ListPointRecordsResponse{}
+	if len(records) > pageSize {
+		records = records[:pageSize]
+		resp.NextCursor = strconv.FormatInt(records[pageSize-1].ID, 10)
+	}
+	resp.Records = make([]*v1.PointRecord, 0, len(records))
+	for _, record := range records {
+		resp.Records = append(resp.Records, toPointRecordProto(record))
+	}
+
+	return resp, nil
+}
+
+// ReversePointRecord 冲正一条积分记录，原记录包含经验变化时重新计算等级
+// 管理员和服务账号权限由 interceptors.AuthzPolicy 检查
+func (s *UserService) ReversePointRecord(ctx context.Context, req *v1.ReversePointRecordRequest) (*v1.ReversePointRecordResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.ReversePointRecord")
+	defer span.End()
+
+	_, err := meta.GetMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.RecordId <= 0 {
+		return &v1.ReversePointRecordResponse{
+			Success:   false,
+			Message:   "积分记录ID不能为空",
+			ErrorCode: v1.ErrorCode_INVALID_REQUEST,
+		}, nil
+	}
+
+	// 操作人记入审计记录，服务账号记为 service:服务名
+	userClaims, ok := ctx.Value("claims").(*utils.Claims)
+	if !ok {
+		return nil, status.Errorf(400, "failed to get user claims from context")
+	}
+	operatorID := strconv.FormatInt(userClaims.UserID, 10)
+	if service, ok := ctx.Value("service").(string); ok && service != "" {
+		operatorID = "service:" + service
+	}
+
+	reversal, err := s.pointRepo.ReversePointRecord(ctx, req.RecordId, operatorID)
+	switch {
+	case errors.Is(err, po.ErrAlreadyReversed):
+		return &v1.ReversePointRecordResponse{
+			Success:   false,
+			Message:   "积分记录已经被冲正",
+			ErrorCode: v1.ErrorCode_INVALID_REQUEST,
+		}, nil
+	case errors.Is(err, po.ErrRecordNotReversible):
+		return &v1.ReversePointRecordResponse{
+			Success:   false,
+			Message:   "该积分记录不能冲正",
+			ErrorCode: v1.ErrorCode_INVALID_REQUEST,
+		}, nil
+	case errors.Is(err, po.ErrPointsInsufficient):
+		metrics.ObservePointsInsufficient(v1.UserService_ReversePointRecord_FullMethodName)
+		return &v1.ReversePointRecordResponse{
+			Success:   false,
+			Message:   "积分不足或该批次积分已使用、过期，无法冲正",
+			ErrorCode: v1.ErrorCode_POINTS_INSUFFICIENT,
+		}, nil
+	case errors.Is(err, po.ErrPointRecordNotFound):
+		return nil, status.Errorf(codes.NotFound, "积分记录不存在")
+	case errors.Is(err, po.ErrUserNotFound):
+		return nil, status.Errorf(codes.NotFound, "用户不存在")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "冲正积分记录失败: %v", err)
+	}
+
+	resp := &v1.ReversePointRecordResponse{
+		Success:   true,
+		Message:   "冲正成功",
+		ErrorCode: v1.ErrorCode_NONE_ERROR,
+		Record:    toPointRecordProto(reversal),
+	}
+	// 原记录有经验变化时，冲正后可能降级或升级
+	if reversal.Experience != 0 {
+		oldLevel, newLevel, err := s.userRepo.UpdateLevelByExperience(ctx, reversal.UserID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "更新等级失败: %v", err)
+		}
+		resp.LevelChange = toLevelChangeProto(oldLevel, newLevel)
+	}
+
+	return resp, nil
+}
+
+// toPointRecordProto 将积分记录模型转换为 proto 消息
+func toPointRecordProto(record *po.PointRecord) *v1.PointRecord {
+	result := &v1.PointRecord{
+		Id:            record.ID,
+		UserId:        record.UserID,
+		Points:        record.Points,
+		Experience:    record.Experience,
+		Reason:        record.Reason,
+		CreatedAt:     record.CreatedAt.Unix(),
+		TransferId:    record.TransferID,
+		RelatedUserId: record.RelatedUserID,
+		RefId:         record.RefID,
+	}
+	if record.ReversesID != nil {
+		result.ReversesId = *record.ReversesID
+	}
+	return result
+}
@@ -0,0 +1,330 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/trancecho/mundo-points-system/pkg/meta"
+	"github.com/trancecho/mundo-points-system/pkg/metrics"
+	"github.com/trancecho/mundo-points-system/pkg/tracing"
+	"github.com/trancecho/mundo-points-system/pkg/utils"
+	"github.com/trancecho/mundo-points-system/po"
+	v1 "github.com/trancecho/mundo-points-system/proto/point/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultOrderPageSize = 20
+	maxOrderPageSize     = 100
+	// maxRedeemQuantity 单次兑换的最大数量
+	maxRedeemQuantity = 100
+	// maxRewardPrice 商品单价上限，与 maxRedeemQuantity 相乘不会溢出
+	maxRewardPrice = 1_000_000_000
+)
+
+// ListRewardItems 获取积分商城商品列表，默认只返回当前可以兑换的商品
+func (s *UserService) ListRewardItems(ctx context.Context, req *v1.ListRewardItemsRequest) (*v1.ListRewardItemsResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.ListRewardItems")
+	defer span.End()
+
+	_, err := meta.GetMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 查看未上架商品的权限由 interceptors.AuthzPolicy 检查
+	availableAt := time.Now()
+	if req.IncludeUnavailable {
+		availableAt = time.Time{}
+	}
+
+	items, err := s.shopRepo.ListRewardItems(ctx, availableAt)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "获取商品列表失败: %v", err)
+	}
+
+	resp := &v1.ListRewardItemsResponse{
+		Items: make([]*v1.RewardItem, 0, len(items)),
+	}
+	for _, item := range items {
+		resp.Items = append(resp.Items, toRewardItemProto(item))
+	}
+	return resp, nil
+}
+
+// RedeemItem 当前用户使用积分兑换商品
+func (s *UserService) RedeemItem(ctx context.Context, req *v1.RedeemItemRequest) (*v1.RedeemItemResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.RedeemItem")
+	defer span.End()
+
+	_, err := meta.GetMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userClaims, ok := ctx.Value("claims").(*utils.Claims)
+	if !ok {
+		return nil, status.Errorf(400, "failed to get user claims from context")
+	}
+
+	quantity := req.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+	if req.ItemId <= 0 || quantity < 0 {
+		return &v1.RedeemItemResponse{
+			Success:   false,
+			Message:   "商品和兑换数量不能为空",
+			ErrorCode: v1.ErrorCode_INVALID_REQUEST,
+		}, nil
+	}
+	if quantity > maxRedeemQuantity {
+		return &v1.RedeemItemResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("单次最多兑换 %d 件", maxRedeemQuantity),
+			ErrorCode: v1.ErrorCode_INVALID_REQUEST,
+		}, nil
+	}
+
+	userID := strconv.FormatInt(userClaims.UserID, 10)
+	order, err := s.shopRepo.RedeemItem(ctx, userID, req.ItemId, quantity)
+	switch {
+	case errors.Is(err, po.ErrPointsInsufficient):
+		metrics.ObservePointsInsufficient(v1.UserService_RedeemItem_FullMethodName)
+		return &v1.RedeemItemResponse{
+			Success:   false,
+			Message:   "积分不足",
+			ErrorCode: v1.ErrorCode_POINTS_INSUFFICIENT,
+		}, nil
+	case errors.Is(err, po.ErrOutOfStock):
+		return &v1.RedeemItemResponse{
+			Success:   false,
+			Message:   "库存不足",
+			ErrorCode: v1.ErrorCode_OUT_OF_STOCK,
+		}, nil
+	case errors.Is(err, po.ErrRedeemLimitExceeded):
+		return &v1.RedeemItemResponse{
+			Success:   false,
+			Message:   "超出每人兑换数量上限",
+			ErrorCode: v1.ErrorCode_LIMIT_EXCEEDED,
+		}, nil
+	case errors.Is(err, po.ErrRewardItemUnavailable):
+		return &v1.RedeemItemResponse{
+			Success:   false,
+			Message:   "商品当前不可兑换",
+			ErrorCode: v1.ErrorCode_INVALID_REQUEST,
+		}, nil
+	case errors.Is(err, po.ErrRewardItemNotFound):
+		return nil, status.Errorf(codes.NotFound, "商品不存在")
+	case errors.Is(err, po.ErrUserNotFound):
+		return nil, status.Errorf(codes.NotFound, "用户不存在")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "兑换失败: %v", err)
+	}
+
+	return &v1.RedeemItemResponse{
+		Success:   true,
+		Message:   "兑换成功",
+		ErrorCode: v1.ErrorCode_NONE_ERROR,
+		Order:     toRedeemOrderProto(order),
+	}, nil
+}
+
+// CreateRewardItem 新建商品，管理员权限由 interceptors.AuthzPolicy 检查
+func (s *UserService) CreateRewardItem(ctx context.Context, req *v1.RewardItem) (*v1.RewardItemResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.CreateRewardItem")
+	defer span.End()
+
+	_, err := meta.GetMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if msg := validateRewardItem(req); msg != "" {
+		return &v1.RewardItemResponse{
+			Success:   false,
+			Message:   msg,
+			ErrorCode: v1.ErrorCode_INVALID_REQUEST,
+		}, nil
+	}
+
+	item := fromRewardItemProto(req)
+	item.ID = 0
+	if err := s.shopRepo.CreateRewardItem(ctx, item); err != nil {
+		return nil, status.Errorf(codes.Internal, "新建商品失败: %v", err)
+	}
+
+	return &v1.RewardItemResponse{
+		Success:   true,
+		Message:   "新建商品成功",
+		ErrorCode: v1.ErrorCode_NONE_ERROR,
+		Item:      toRewardItemProto(item),
+	}, nil
+}
+
+// UpdateRewardItem 更新商品，管理员权限由 interceptors.AuthzPolicy 检查
+func (s *UserService) UpdateRewardItem(ctx context.Context, req *v1.RewardItem) (*v1.RewardItemResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateRewardItem")
+	defer span.End()
+
+	_, err := meta.GetMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Id <= 0 {
+		return &v1.RewardItemResponse{
+			Success:   false,
+			Message:   "商品ID不能为空",
+			ErrorCode: v1.ErrorCode_INVALID_REQUEST,
+		}, nil
+	}
+	if msg := validateRewardItem(req); msg != "" {
+		return &v1.RewardItemResponse{
+			Success:   false,
+			Message:   msg,
+			ErrorCode: v1.ErrorCode_INVALID_REQUEST,
+		}, nil
+	}
+
+	item := fromRewardItemProto(req)
+	err = s.shopRepo.UpdateRewardItem(ctx, item)
+	if errors.Is(err, po.ErrRewardItemNotFound) {
+		return nil, status.Errorf(codes.NotFound, "商品不存在")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "更新商品失败: %v", err)
+	}
+
+	return &v1.RewardItemResponse{
+		Success:   true,
+		Message:   "更新商品成功",
+		ErrorCode: v1.ErrorCode_NONE_ERROR,
+		Item:      toRewardItemProto(item),
+	}, nil
+}
+
+// ListRedeemOrders 分页查询兑换订单，管理员权限由 interceptors.AuthzPolicy 检查
+func (s *UserService) ListRedeemOrders(ctx context.Context, req *v1.ListRedeemOrdersRequest) (*v1.ListRedeemOrdersResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.ListRedeemOrders")
+	defer span.End()
+
+	_, err := meta.GetMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := po.RedeemOrderFilter{
+		UserID: req.UserId,
+		ItemID: req.ItemId,
+		Limit:  int(req.PageSize),
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultOrderPageSize
+	} else if filter.Limit > maxOrderPageSize {
+		filter.Limit = maxOrderPageSize
+	}
+	if req.Cursor != "" {
+		filter.BeforeID, err = strconv.ParseInt(req.Cursor, 10, 64)
+		if err != nil || filter.BeforeID <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "无效的分页游标")
+		}
+	}
+
+	// 多取一条用于判断是否还有下一页
+	pageSize := filter.Limit
+	filter.Limit++
+	orders, err := s.shopRepo.ListRedeemOrders(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "查询兑换订单失败: %v", err)
+	}
+
+	resp := &v1.ListRedeemOrdersResponse{}
+	if len(orders) > pageSize {
+		orders = orders[:pageSize]
+		resp.NextCursor = strconv.FormatInt(orders[pageSize-1].ID, 10)
+	}
+	resp.Orders = make([]*v1.RedeemOrder, 0, len(orders))
+	for _, order := range orders {
+		resp.Orders = append(resp.Orders, toRedeemOrderProto(order))
+	}
+
+	return resp, nil
+}
+
+// validateRewardItem 检查商品字段，返回错误提示，合法时返回空字符串
+func validateRewardItem(item *v1.RewardItem) string {
+	switch {
+	case item.Name == "":
+		return "商品名称不能为空"
+	case item.Price <= 0:
+		return "商品价格必须大于0"
+	case item.Price > maxRewardPrice:
+		return fmt.Sprintf("商品价格不能超过 %d", maxRewardPrice)
+	case item.Stock < 0:
+		return "库存不能小于0"
+	case item.PerUserLimit < 0:
+		return "每人兑换上限不能小于0"
+	case item.StartTime > 0 && item.EndTime > 0 && item.EndTime <= item.StartTime:
+		return "结束时间必须晚于开始时间"
+	}
+	return ""
+}
+
+func fromRewardItemProto(item *v1.RewardItem) *po.RewardItem {
+	result := &po.RewardItem{
+		Name:         item.Name,
+		Description:  item.Description,
+		Price:        item.Price,
+		Stock:        item.Stock,
+		PerUserLimit: item.PerUserLimit,
+		Active:       item.Active,
+	}
+	result.ID = item.Id
+	if item.StartTime > 0 {
+		startAt := time.Unix(item.StartTime, 0)
+		result.StartAt = &startAt
+	}
+	if item.EndTime > 0 {
+		endAt := time.Unix(item.EndTime, 0)
+		result.EndAt = &endAt
+	}
+	return result
+}
+
+func toRewardItemProto(item *po.RewardItem) *v1.RewardItem {
+	result := &v1.RewardItem{
+		Id:           item.ID,
+		Name:         item.Name,
+		Description:  item.Description,
+		Price:        item.Price,
+		Stock:        item.Stock,
+		PerUserLimit: item.PerUserLimit,
+		Active:       item.Active,
+	}
+	if item.StartAt != nil {
+		result.StartTime = item.StartAt.Unix()
+	}
+	if item.EndAt != nil {
+		result.EndTime = item.EndAt.Unix()
+	}
+	return result
+}
+
+func toRedeemOrderProto(order *po.RedeemOrder) *v1.RedeemOrder {
+	result := &v1.RedeemOrder{
+		Id:        order.ID,
+		OrderId:   order.OrderID,
+		UserId:    order.UserID,
+		ItemId:    order.ItemID,
+		ItemName:  order.ItemName,
+		Quantity:  order.Quantity,
+		Points:    order.Points,
+		CreatedAt: order.CreatedAt.Unix(),
+	}
+	if order.CancelledAt != nil {
+		result.CancelledAt = order.CancelledAt.Unix()
+	}
+	return result
+}
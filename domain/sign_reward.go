@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/spf13/viper"
+)
+
+// ActivityTier 活跃度加成档位，活跃度达到 MinScore 时基础奖励按 Bonus 比例加成
+type ActivityTier struct {
+	MinScore int64   `mapstructure:"min_score"`
+	Bonus    float64 `mapstructure:"bonus"`
+}
+
+// StreakBonus 连续签到里程碑奖励，连续签到恰好达到 Day 天时额外发放
+type StreakBonus struct {
+	Day        int32 `mapstructure:"day"`
+	Points     int64 `mapstructure:"points"`
+	Experience int64 `mapstructure:"experience"`
+	Activity   int64 `mapstructure:"activity"`
+}
+
+// SignRewardRules 签到奖励规则
+type SignRewardRules struct {
+	BasePoints     int64          `mapstructure:"base_points"`
+	BaseExperience int64          `mapstructure:"base_experience"`
+	BaseActivity   int64          `mapstructure:"base_activity"`
+	ActivityTiers  []ActivityTier `mapstructure:"activity_tiers"`
+	StreakBonuses  []StreakBonus  `mapstructure:"streak_bonuses"`
+}
+
+// SignReward 一次签到的奖励
+type SignReward struct {
+	Points     int64
+	Experience int64
+	Activity   int64
+}
+
+// DefaultSignRewardRules 未配置时使用的默认签到奖励规则
+func DefaultSignRewardRules() SignRewardRules {
+	return SignRewardRules{
+		BasePoints:     50,
+		BaseExperience: 10,
+		BaseActivity:   5,
+		ActivityTiers: []ActivityTier{
+			{MinScore: 10000, Bonus: 0.5},
+			{MinScore: 5000, Bonus: 0.3},
+			{MinScore: 2000, Bonus: 0.2},
+			{MinScore: 500, Bonus: 0.1},
+		},
+	}
+}
+
+// LoadSignRewardRules 从配置项 sign_reward 读取签到奖励规则，未配置的字段使用默认值
+func LoadSignRewardRules() (SignRewardRules, error) {
+	rules := DefaultSignRewardRules()
+	// 配置了列表时整体替换默认值，避免和默认档位按下标合并
+	if viper.IsSet("sign_reward.activity_tiers") {
+		rules.ActivityTiers = nil
+	}
+	if viper.IsSet("sign_reward.streak_bonuses") {
+		rules.StreakBonuses = nil
+	}
+	if err := viper.UnmarshalKey("sign_reward", &rules); err != nil {
+		return SignRewardRules{}, err
+	}
+	if err := rules.Validate(); err != nil {
+		return SignRewardRules{}, err
+	}
+	return rules, nil
+}
+
+// Validate 检查规则是否合法
+func (r SignRewardRules) Validate() error {
+	if r.BasePoints < 0 || r.BaseExperience < 0 || r.BaseActivity < 0 {
+		return errors.New("签到基础奖励不能为负数")
+	}
+	for _, tier := range r.ActivityTiers {
+		if tier.MinScore < 0 || tier.Bonus < 0 {
+			return errors.New("活跃度加成档位不能为负数")
+		}
+	}
+	for _, bonus := range r.StreakBonuses {
+		if bonus.Day <= 0 {
+			return errors.New("连续签到里程碑天数必须大于0")
+		}
+		if bonus.Points < 0 || bonus.Experience < 0 || bonus.Activity < 0 {
+			return errors.New("连续签到里程碑奖励不能为负数")
+		}
+	}
+	return nil
+}
+
+// Calculate 根据用户活跃度和本次签到后的连续签到天数计算奖励
+func (r SignRewardRules) Calculate(activityScore int64, continuousDay int32) SignReward {
+	reward := SignReward{
+		Points:     r.BasePoints,
+		Experience: r.BaseExperience,
+		Activity:   r.BaseActivity,
+	}
+
+	// 取满足条件的最高档活跃度加成
+	activityBonus := 0.0
+	for _, tier := range r.ActivityTiers {
+		if activityScore >= tier.MinScore && tier.Bonus > activityBonus {
+			activityBonus = tier.Bonus
+		}
+	}
+	reward.Points += int64(float64(reward.Points) * activityBonus)
+	reward.Experience += int64(float64(reward.Experience) * activityBonus)
+
+	// 连续签到里程碑奖励不参与活跃度加成
+	for _, bonus := range r.StreakBonuses {
+		if bonus.Day == continuousDay {
+			reward.Points += bonus.Points
+			reward.Experience += bonus.Experience
+			reward.Activity += bonus.Activity
+		}
+	}
+
+	return reward
+}
+
+// SignRewardEngine 签到奖励规则引擎，支持运行时替换规则
+type SignRewardEngine struct {
+	rules atomic.Pointer[SignRewardRules]
+}
+
+// NewSignRewardEngine 创建签到奖励规则引擎
+func NewSignRewardEngine(rules SignRewardRules) *SignRewardEngine {
+	engine := &SignRewardEngine{}
+	engine.Store(rules)
+	return engine
+}
+
+// Store 替换当前生效的规则
+func (e *SignRewardEngine) Store(rules SignRewardRules) {
+	// 复制一份，避免调用方后续修改影响已生效的规则
+	rules.ActivityTiers = append([]ActivityTier(nil), rules.ActivityTiers...)
+	rules.StreakBonuses = append([]StreakBonus(nil), rules.StreakBonuses...)
+	e.rules.Store(&rules)
+}
+
+// Reload 从配置重新加载规则，加载失败时保留原规则
+func (e *SignRewardEngine) Reload() error {
+	rules, err := LoadSignRewardRules()
+	if err != nil {
+		return err
+	}
+	e.Store(rules)
+	return nil
+}
+
+// Rules 返回当前生效的规则
+func (e *SignRewardEngine) Rules() SignRewardRules {
+	return *e.rules.Load()
+}
+
+// Calculate 使用当前生效的规则计算签到奖励
+func (e *SignRewardEngine) Calculate(activityScore int64, continuousDay int32) SignReward {
+	return e.rules.Load().Calculate(activityScore, continuousDay)
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestSignRewardActivityTiers(t *testing.T) {
+	rules := DefaultSignRewardRules()
+	tests := []struct {
+		name          string
+		activityScore int64
+		want          SignReward
+	}{
+		{name: "未达到任何档位", activityScore: 499, want: SignReward{Points: 50, Experience: 10, Activity: 5}},
+		{name: "刚好达到最低档", activityScore: 500, want: SignReward{Points: 55, Experience: 11, Activity: 5}},
+		{name: "中间档", activityScore: 2000, want: SignReward{Points: 60, Experience: 12, Activity: 5}},
+		{name: "第二高档", activityScore: 9999, want: SignReward{Points: 65, Experience: 13, Activity: 5}},
+		{name: "最高档", activityScore: 10000, want: SignReward{Points: 75, Experience: 15, Activity: 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rules.Calculate(tt.activityScore, 1); got != tt.want {
+				t.Fatalf("Calculate(%d) = %+v, want %+v", tt.activityScore, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignRewardTierOrderDoesNotMatter(t *testing.T) {
+	rules := SignRewardRules{
+		BasePoints: 100,
+		// 档位乱序时仍取满足条件的最高加成
+		ActivityTiers: []ActivityTier{
+			{MinScore: 100, Bonus: 0.1},
+			{MinScore: 1000, Bonus: 0.5},
+			{MinScore: 500, Bonus: 0.2},
+		},
+	}
+	if got := rules.Calculate(2000, 1).Points; got != 150 {
+		t.Fatalf("积分 = %d, want 150", got)
+	}
+	if got := rules.Calculate(600, 1).Points; got != 120 {
+		t.Fatalf("积分 = %d, want 120", got)
+	}
+}
+
+func TestSignRewardStreakBonuses(t *testing.T) {
+	rules := SignRewardRules{
+		BasePoints:     10,
+		BaseExperience: 2,
+		BaseActivity:   1,
+		ActivityTiers:  []ActivityTier{{MinScore: 0, Bonus: 1}},
+		StreakBonuses: []StreakBonus{
+			{Day: 7, Points: 30, Experience: 5, Activity: 2},
+			{Day: 30, Points: 100, Experience: 20, Activity: 10},
+		},
+	}
+	tests := []struct {
+		name          string
+		continuousDay int32
+		want          SignReward
+	}{
+		{name: "不是里程碑", continuousDay: 6, want: SignReward{Points: 20, Experience: 4, Activity: 1}},
+		// 里程碑奖励不参与活跃度加成
+		{name: "第7天", continuousDay: 7, want: SignReward{Points: 50, Experience: 9, Activity: 3}},
+		{name: "超过里程碑不重复发放", continuousDay: 8, want: SignReward{Points: 20, Experience: 4, Activity: 1}},
+		{name: "第30天", continuousDay: 30, want: SignReward{Points: 120, Experience: 24, Activity: 11}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rules.Calculate(0, tt.continuousDay); got != tt.want {
+				t.Fatalf("Calculate(day %d) = %+v, want %+v", tt.continuousDay, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignRewardValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules SignRewardRules
+	}{
+		{name: "基础奖励为负", rules: SignRewardRules{BasePoints: -1}},
+		{name: "档位加成为负", rules: SignRewardRules{ActivityTiers: []ActivityTier{{MinScore: 10, Bonus: -0.1}}}},
+		{name: "里程碑天数为0", rules: SignRewardRules{StreakBonuses: []StreakBonus{{Day: 0, Points: 1}}}},
+		{name: "里程碑奖励为负", rules: SignRewardRules{StreakBonuses: []StreakBonus{{Day: 3, Experience: -1}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rules.Validate(); err == nil {
+				t.Fatal("Validate 应返回错误")
+			}
+		})
+	}
+	if err := DefaultSignRewardRules().Validate(); err != nil {
+		t.Fatalf("默认规则不合法: %v", err)
+	}
+}
+
+// setConfig 测试期间设置配置项，结束后清空全部配置
+func setConfig(t *testing.T, values map[string]interface{}) {
+	t.Helper()
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	for key, value := range values {
+		viper.Set(key, value)
+	}
+}
+
+func TestLoadSignRewardRulesReplacesLists(t *testing.T) {
+	setConfig(t, map[string]interface{}{
+		"sign_reward.base_points": 20,
+		"sign_reward.activity_tiers": []map[string]interface{}{
+			{"min_score": 100, "bonus": 0.5},
+		},
+	})
+
+	rules, err := LoadSignRewardRules()
+	if err != nil {
+		t.Fatalf("LoadSignRewardRules: %v", err)
+	}
+	if rules.BasePoints != 20 || rules.BaseExperience != 10 {
+		t.Fatalf("未配置的字段应使用默认值: %+v", rules)
+	}
+	if len(rules.ActivityTiers) != 1 || rules.ActivityTiers[0].MinScore != 100 {
+		t.Fatalf("配置的档位应整体替换默认档位: %+v", rules.ActivityTiers)
+	}
+}
+
+func TestSignRewardEngineReloadKeepsRulesOnBadConfig(t *testing.T) {
+	engine := NewSignRewardEngine(DefaultSignRewardRules())
+	before := engine.Calculate(10000, 1)
+
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+	}{
+		{name: "校验失败", config: map[string]interface{}{"sign_reward.base_points": -5}},
+		{name: "类型错误", config: map[string]interface{}{"sign_reward.activity_tiers": "not a list"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setConfig(t, tt.config)
+			if err := engine.Reload(); err == nil {
+				t.Fatal("Reload 应返回错误")
+			}
+			if got := engine.Calculate(10000, 1); got != before {
+				t.Fatalf("加载失败后规则被修改: %+v, want %+v", got, before)
+			}
+		})
+	}
+
+	setConfig(t, map[string]interface{}{"sign_reward.base_points": 80})
+	if err := engine.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if got := engine.Calculate(0, 1).Points; got != 80 {
+		t.Fatalf("重新加载后基础积分 = %d, want 80", got)
+	}
+}
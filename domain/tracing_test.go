@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/trancecho/mundo-points-system/pkg/testdb"
+	"github.com/trancecho/mundo-points-system/pkg/tracing"
+	"github.com/trancecho/mundo-points-system/pkg/utils"
+	"github.com/trancecho/mundo-points-system/po/repository"
+	v1 "github.com/trancecho/mundo-points-system/proto/point/v1"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"gorm.io/gorm"
+)
+
+// newTestService 使用真实仓库创建 UserService
+func newTestService(db *gorm.DB) *UserService {
+	levels := NewLevelEngine(DefaultLevelCurve())
+	return NewUserService(
+		repository.NewUserRepository(db, levels),
+		repository.NewPointRepository(db),
+		repository.NewStatisticsRepository(db),
+		repository.NewShopRepository(db),
+		NewSignRewardEngine(DefaultSignRewardRules()),
+		levels,
+	)
+}
+
+// userContext 模拟拦截器处理后的请求上下文
+func userContext(userID int64, username string) context.Context {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{})
+	return context.WithValue(ctx, "claims", &utils.Claims{UserID: userID, Username: username})
+}
+
+func TestServiceSpanParentsGormSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.NewProvider(exporter)
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		_ = provider.Shutdown(context.Background())
+	})
+
+	db := testdb.Open(t)
+	if err := db.Use(tracing.GormPlugin{}); err != nil {
+		t.Fatalf("注册 GORM 插件失败: %v", err)
+	}
+	service := newTestService(db)
+
+	resp, err := service.EnsureUser(userContext(1, "alice"), &v1.EnsureUserRequest{})
+	if err != nil || !resp.Created {
+		t.Fatalf("EnsureUser = %+v, %v", resp, err)
+	}
+	if err := provider.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	var root *tracetest.SpanStub
+	for i := range spans {
+		if spans[i].Name == "UserService.EnsureUser" {
+			root = &spans[i]
+		}
+	}
+	if root == nil {
+		t.Fatalf("缺少 UserService.EnsureUser span，共 %d 个 span", len(spans))
+	}
+	if root.Parent.IsValid() {
+		t.Fatalf("UserService.EnsureUser 不应有父 span")
+	}
+
+	names := map[string]int{}
+	for _, span := range spans {
+		if !strings.HasPrefix(span.Name, "gorm.") {
+			continue
+		}
+		names[span.Name]++
+		if span.SpanContext.TraceID() != root.SpanContext.TraceID() {
+			t.Errorf("%s 不在同一条链路中", span.Name)
+		}
+		if span.Parent.SpanID() != root.SpanContext.SpanID() {
+			t.Errorf("%s 的父 span 不是 UserService.EnsureUser", span.Name)
+		}
+	}
+	// 先查询用户是否存在，再创建用户和新用户奖励记录
+	if names["gorm.query"] == 0 || names["gorm.create"] < 2 {
+		t.Fatalf("GORM span 不完整: %v", names)
+	}
+}
+
+func TestIncomingTraceparentParentsServerSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.NewProvider(exporter)
+	otel.SetTracerProvider(provider)
+	propagator := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagator)
+		_ = provider.Shutdown(context.Background())
+	})
+
+	// 与 main.go 一样由 stats handler 从 metadata 中提取上游的 trace 上下文
+	client := startTestServer(t, testdb.Open(t), grpc.StatsHandler(otelgrpc.NewServerHandler()))
+
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+	ctx := metadata.AppendToOutgoingContext(withToken(t, 1, "alice"), "traceparent", "00-"+traceID+"-"+spanID+"-01")
+	if _, err := client.ListLevels(ctx, &v1.ListLevelsRequest{}); err != nil {
+		t.Fatalf("ListLevels: %v", err)
+	}
+	if err := provider.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	var server, service *tracetest.SpanStub
+	for i := range spans {
+		switch {
+		case spans[i].SpanKind == trace.SpanKindServer:
+			server = &spans[i]
+		case spans[i].Name == "UserService.ListLevels":
+			service = &spans[i]
+		}
+	}
+	if server == nil || service == nil {
+		t.Fatalf("缺少服务端 span 或 UserService.ListLevels span，共 %d 个 span", len(spans))
+	}
+	if got := server.SpanContext.TraceID().String(); got != traceID {
+		t.Fatalf("服务端 span 的 trace ID = %s, want %s", got, traceID)
+	}
+	if !server.Parent.IsRemote() || server.Parent.SpanID().String() != spanID {
+		t.Fatalf("服务端 span 的父 span = %s, want 上游的 %s", server.Parent.SpanID(), spanID)
+	}
+	if service.Parent.SpanID() != server.SpanContext.SpanID() {
+		t.Fatalf("UserService.ListLevels 的父 span 不是服务端 span")
+	}
+}
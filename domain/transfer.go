@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/spf13/viper"
+	"github.com/trancecho/mundo-points-system/pkg/meta"
+	"github.com/trancecho/mundo-points-system/pkg/metrics"
+	"github.com/trancecho/mundo-points-system/pkg/tracing"
+	"github.com/trancecho/mundo-points-system/pkg/utils"
+	"github.com/trancecho/mundo-points-system/po"
+	v1 "github.com/trancecho/mundo-points-system/proto/point/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultTransferDailyLimit 未配置 transfer.daily_limit 时每个用户每天最多转出的积分
+const defaultTransferDailyLimit = int64(1000)
+
+// transferDailyLimit 每日转出上限，配置为 0 表示不限
+func transferDailyLimit() int64 {
+	if viper.IsSet("transfer.daily_limit") {
+		return viper.GetInt64("transfer.daily_limit")
+	}
+	return defaultTransferDailyLimit
+}
+
+// TransferPoints 当前用户向其他用户转赠积分
+func (s *UserService) TransferPoints(ctx context.Context, req *v1.TransferPointsRequest) (*v1.TransferPointsResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.TransferPoints")
+	defer span.End()
+
+	_, err := meta.GetMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userClaims, ok := ctx.Value("claims").(*utils.Claims)
+	if !ok {
+		return nil, status.Errorf(400, "failed to get user claims from context")
+	}
+
+	fromUserID := strconv.FormatInt(userClaims.UserID, 10)
+	if req.ToUserId == "" || req.Points <= 0 {
+		return &v1.TransferPointsResponse{
+			Success:   false,
+			Message:   "接收方和转账积分不能为空",
+			ErrorCode: v1.ErrorCode_INVALID_REQUEST,
+		}, nil
+	}
+	if req.ToUserId == fromUserID {
+		return &v1.TransferPointsResponse{
+			Success:   false,
+			Message:   "不能给自己转账",
+			ErrorCode: v1.ErrorCode_INVALID_REQUEST,
+		}, nil
+	}
+
+	transferID, err := s.pointRepo.TransferPoints(ctx, fromUserID, req.ToUserId, req.Points, transferDailyLimit())
+	switch {
+	case errors.Is(err, po.ErrPointsInsufficient):
+		metrics.ObservePointsInsufficient(v1.UserService_TransferPoints_FullMethodName)
+		return &v1.TransferPointsResponse{
+			Success:   false,
+			Message:   "积分不足",
+			ErrorCode: v1.ErrorCode_POINTS_INSUFFICIENT,
+		}, nil
+	case errors.Is(err, po.ErrTransferLimitExceeded):
+		return &v1.TransferPointsResponse{
+			Success:   false,
+			Message:   "超出每日转账限额",
+			ErrorCode: v1.ErrorCode_LIMIT_EXCEEDED,
+		}, nil
+	case errors.Is(err, po.ErrUserNotFound):
+		return nil, status.Errorf(codes.NotFound, "用户不存在")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "转账失败: %v", err)
+	}
+
+	return &v1.TransferPointsResponse{
+		Success:    true,
+		Message:    "转账成功",
+		ErrorCode:  v1.ErrorCode_NONE_ERROR,
+		TransferId: transferID,
+	}, nil
+}
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/spf13/viper"
+	"github.com/trancecho/mundo-points-system/pkg/meta"
+	"github.com/trancecho/mundo-points-system/pkg/tracing"
+	"github.com/trancecho/mundo-points-system/pkg/utils"
+	"github.com/trancecho/mundo-points-system/po"
+	v1 "github.com/trancecho/mundo-points-system/proto/point/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBatchUserIDs 批量获取用户信息时一次最多查询的用户数
+const maxBatchUserIDs = 100
+
+// defaultInitialPoints 未配置 user.initial_points 时新用户的初始积分
+const defaultInitialPoints = int64(1200)
+
+// initialPoints 新用户初始积分，记为一条新用户奖励记录
+func initialPoints() int64 {
+	if viper.IsSet("user.initial_points") {
+		return viper.GetInt64("user.initial_points")
+	}
+	return defaultInitialPoints
+}
+
+// EnsureUser 创建用户，用户已存在时返回现有信息
+func (s *UserService) EnsureUser(ctx context.Context, req *v1.EnsureUserRequest) (*v1.EnsureUserResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.EnsureUser")
+	defer span.End()
+
+	_, err := meta.GetMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userClaims, ok := ctx.Value("claims").(*utils.Claims)
+	if !ok {
+		return nil, status.Errorf(400, "failed to get user claims from context")
+	}
+
+	// 默认创建当前用户，为其他用户创建的权限由 interceptors.AuthzPolicy 检查
+	userID := userClaims.UserID
+	username := userClaims.Username
+	if req.UserId != "" && req.UserId != strconv.FormatInt(userClaims.UserID, 10) {
+		userID, err = strconv.ParseInt(req.UserId, 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "无效的用户ID")
+		}
+		username = req.Username
+	}
+	if userID <= 0 {
+		return &v1.EnsureUserResponse{
+			Success:   false,
+			Message:   "用户ID不能为空",
+			ErrorCode: v1.ErrorCode_INVALID_REQUEST,
+		}, nil
+	}
+
+	user, created, err := s.userRepo.EnsureUser(ctx, userID, username, initialPoints())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "创建用户失败: %v", err)
+	}
+
+	resp := &v1.EnsureUserResponse{
+		Success:   true,
+		Message:   "用户已存在",
+		ErrorCode: v1.ErrorCode_NONE_ERROR,
+		User:      toUserInfoProto(user),
+		Created:   created,
+	}
+	if created {
+		resp.Message = "创建用户成功"
+	}
+	return resp, nil
+}
+
+// BatchGetUserInfo 批量获取用户信息，只查询一次数据库，不会创建用户
+func (s *UserService) BatchGetUserInfo(ctx context.Context, req *v1.BatchGetUserInfoRequest) (*v1.BatchGetUserInfoResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.BatchGetUserInfo")
+	defer span.End()
+
+	_, err := meta.GetMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 去重，保持请求中的顺序
+	// 只接受规范的十进制ID，否则 "007" 会查到用户 7，响应中却找不到请求的键
+	userIDs := make([]string, 0, len(req.UserIds))
+	seen := make(map[string]bool, len(req.UserIds))
+	for _, userID := range req.UserIds {
+		if id, err := strconv.ParseInt(userID, 10, 64); err != nil || id <= 0 || strconv.FormatInt(id, 10) != userID {
+			return nil, status.Errorf(codes.InvalidArgument, "无效的用户ID: %q", userID)
+		}
+		if !seen[userID] {
+			seen[userID] = true
+			userIDs = append(userIDs, userID)
+		}
+	}
+	if len(userIDs) > maxBatchUserIDs {
+		return nil, status.Errorf(codes.InvalidArgument, "一次最多查询 %d 个用户", maxBatchUserIDs)
+	}
+
+	users, err := s.userRepo.GetUsersByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "获取用户信息失败: %v", err)
+	}
+
+	resp := &v1.BatchGetUserInfoResponse{
+		Users: make(map[string]*v1.UserInfo, len(users)),
+	}
+	for _, user := range users {
+		info := toUserInfoProto(user)
+		resp.Users[info.UserId] = info
+	}
+	for _, userID := range userIDs {
+		if _, ok := resp.Users[userID]; !ok {
+			resp.MissingUserIds = append(resp.MissingUserIds, userID)
+		}
+	}
+	return resp, nil
+}
+
+func toUserInfoProto(user *po.UserInfo) *v1.UserInfo {
+	return &v1.UserInfo{
+		UserId:             strconv.FormatInt(user.UserID, 10),
+		Username:           user.Username,
+		Points:             user.Points,
+		Experience:         user.Experience,
+		Level:              int32(user.Level),
+		IsSigned:           user.IsSigned,
+		ContinuousSignDays: user.ContinuousSignDay,
+		TotalSignDays:      user.TotalSignDay,
+		ActivityScore:      user.ActivityScore,
+	}
+}
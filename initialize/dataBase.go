@@ -2,8 +2,11 @@ package initialize
 
 import (
 	"fmt"
+	"log/slog"
+
+	"github.com/trancecho/mundo-points-system/pkg/logger"
+	"github.com/trancecho/mundo-points-system/pkg/tracing"
 	"github.com/trancecho/mundo-points-system/po"
-	"log"
 
 	"github.com/spf13/viper"
 	"gorm.io/driver/mysql"
@@ -12,8 +15,9 @@ import (
 
 var DB *gorm.DB
 
-// InitDB 初始化数据库连接
-func InitDB() *gorm.DB {
+// InitDB 初始化数据库连接并迁移表结构
+// 连接失败时返回 nil 和连接错误；迁移失败时仍返回连接，同时返回迁移错误。两种情况都不退出，由健康检查对外报告不可用
+func InitDB() (*gorm.DB, error) {
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=%s&parseTime=%v&loc=%s",
 		viper.GetString("database.username"),
 		viper.GetString("database.password"),
@@ -25,15 +29,131 @@ func InitDB() *gorm.DB {
 		viper.GetString("database.loc"),
 	)
 	var err error
-	DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{
+		// 将唯一键冲突等驱动错误转换为 gorm.ErrDuplicatedKey 等通用错误
+		TranslateError: true,
+	})
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		slog.Error("Failed to connect to database", "error", err)
+		return nil, fmt.Errorf("连接数据库失败: %w", err)
+	}
+	// 每次数据库调用生成一个 span
+	if err = DB.Use(tracing.GormPlugin{}); err != nil {
+		logger.Fatal("Failed to register gorm tracing plugin", "error", err)
 	}
 
-	err = DB.AutoMigrate(&po.UserInfo{}, &po.LikeRecord{}, &po.PointRecord{})
+	err = Migrate(DB)
 	if err != nil {
-		log.Printf("Database migrate failed: %v", err)
-		return nil
+		slog.Error("Database migrate failed", "error", err)
+		return DB, err
 	} // 自动迁移表结构
-	return DB
+	return DB, nil
+}
+
+// Migrate 迁移表结构
+func Migrate(db *gorm.DB) error {
+	if err := dedupeLikeRecords(db); err != nil {
+		return err
+	}
+	err := db.AutoMigrate(&po.UserInfo{}, &po.LikeRecord{}, &po.PointRecord{}, &po.LevelChangeRecord{}, &po.RewardItem{}, &po.RedeemOrder{}, &po.AdminAuditRecord{})
+	if err != nil {
+		return err
+	}
+	return migrateIndexes(db)
+}
+
+// likeUniqueIndex 同一用户对同一帖子只有一条点赞记录的唯一索引，见 po.LikeRecord
+const likeUniqueIndex = "idx_like_user_post"
+
+// dedupeLikeRecords 在创建点赞唯一索引之前合并重复的点赞记录，否则建索引失败导致迁移失败
+// 旧版本并发点赞时可能写入多条相同用户和帖子的记录。每组保留一条：优先保留未取消的最早记录，全部取消时保留最新的记录；
+// 未取消的记录中任一条的积分还在被点赞者手中时，保留的记录标记为已发放，取消点赞时仍会收回积分
+func dedupeLikeRecords(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&po.LikeRecord{}) || db.Migrator().HasIndex(&po.LikeRecord{}, likeUniqueIndex) {
+		return nil
+	}
+
+	var groups []struct {
+		UserID string
+		PostID string
+	}
+	if err := db.Model(&po.LikeRecord{}).Unscoped().
+		Select("user_id, post_id").
+		Group("user_id, post_id").
+		Having("COUNT(*) > 1").
+		Find(&groups).Error; err != nil {
+		return fmt.Errorf("查询重复的点赞记录失败: %w", err)
+	}
+
+	for _, group := range groups {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var records []po.LikeRecord
+			if err := tx.Unscoped().
+				Where("user_id = ? AND post_id = ?", group.UserID, group.PostID).
+				Order("id ASC").
+				Find(&records).Error; err != nil {
+				return err
+			}
+			if len(records) < 2 {
+				return nil
+			}
+
+			keep := records[len(records)-1]
+			active := false
+			rewarded := false
+			for _, record := range records {
+				if record.DeletedAt.Valid {
+					continue
+				}
+				if !active {
+					keep = record
+					active = true
+				}
+				rewarded = rewarded || record.Rewarded
+			}
+			if !active {
+				rewarded = keep.Rewarded
+			}
+
+			if rewarded != keep.Rewarded {
+				if err := tx.Unscoped().Model(&keep).Update("rewarded", rewarded).Error; err != nil {
+					return err
+				}
+			}
+			return tx.Unscoped().
+				Where("user_id = ? AND post_id = ? AND id <> ?", group.UserID, group.PostID, keep.ID).
+				Delete(&po.LikeRecord{}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("合并重复的点赞记录失败: %w", err)
+		}
+	}
+	if len(groups) > 0 {
+		slog.Warn("合并了重复的点赞记录", "groups", len(groups))
+	}
+	return nil
+}
+
+// extraIndexes 无法用模型标签声明的索引，如包含 BaseModel 字段的联合索引
+var extraIndexes = []struct {
+	Table   string
+	Name    string
+	Columns string
+}{
+	// 周期排行榜按时间范围筛选积分记录后按用户汇总
+	{Table: "point_records", Name: "idx_point_records_created_user", Columns: "created_at, user_id"},
+}
+
+// migrateIndexes 创建 extraIndexes 中还不存在的索引
+func migrateIndexes(db *gorm.DB) error {
+	for _, index := range extraIndexes {
+		if db.Migrator().HasIndex(index.Table, index.Name) {
+			continue
+		}
+		sql := fmt.Sprintf("CREATE INDEX %s ON %s (%s)", index.Name, index.Table, index.Columns)
+		if err := db.Exec(sql).Error; err != nil {
+			return fmt.Errorf("创建索引 %s 失败: %w", index.Name, err)
+		}
+	}
+	return nil
 }
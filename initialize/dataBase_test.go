@@ -0,0 +1,98 @@
+package initialize_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/trancecho/mundo-points-system/initialize"
+	"github.com/trancecho/mundo-points-system/po"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// legacyLikeRecord 加唯一索引之前的点赞记录表结构
+type legacyLikeRecord struct {
+	po.BaseModel
+	UserID       string `gorm:"column:user_id;not null;index"`
+	PostID       string `gorm:"column:post_id;not null;index"`
+	TargetUserID string `gorm:"column:target_user_id;not null;index"`
+	Rewarded     bool   `gorm:"column:rewarded;not null;default:true"`
+}
+
+func (legacyLikeRecord) TableName() string {
+	return "like_records"
+}
+
+func TestMigrateDedupesLikeRecords(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:migrate_likes?mode=memory&cache=shared"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	if err := db.AutoMigrate(&legacyLikeRecord{}); err != nil {
+		t.Fatal(err)
+	}
+
+	deleted := gorm.DeletedAt{Time: time.Now(), Valid: true}
+	rows := []legacyLikeRecord{
+		// 并发点赞写入的两条记录，保留较早的一条
+		{UserID: "1", PostID: "a", TargetUserID: "9", Rewarded: true},
+		{UserID: "1", PostID: "a", TargetUserID: "9", Rewarded: true},
+		// 取消后的记录和未取消的记录，保留未取消的，已发放状态取未取消的记录
+		{UserID: "1", PostID: "b", TargetUserID: "9", Rewarded: false, BaseModel: po.BaseModel{DeletedAt: deleted}},
+		{UserID: "1", PostID: "b", TargetUserID: "9", Rewarded: true},
+		// 全部取消，保留最新的一条
+		{UserID: "2", PostID: "a", TargetUserID: "9", Rewarded: false, BaseModel: po.BaseModel{DeletedAt: deleted}},
+		{UserID: "2", PostID: "a", TargetUserID: "9", Rewarded: false, BaseModel: po.BaseModel{DeletedAt: deleted}},
+		// 没有重复的记录不受影响
+		{UserID: "3", PostID: "a", TargetUserID: "9", Rewarded: true},
+	}
+	if err := db.Create(&rows).Error; err != nil {
+		t.Fatal(err)
+	}
+	// rewarded 有默认值，创建时不会写入 false
+	if err := db.Unscoped().Model(&legacyLikeRecord{}).
+		Where("id IN ?", []int64{rows[2].ID, rows[4].ID, rows[5].ID}).
+		Update("rewarded", false).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := initialize.Migrate(db); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if !db.Migrator().HasIndex(&po.LikeRecord{}, "idx_like_user_post") {
+		t.Fatal("没有创建点赞唯一索引")
+	}
+
+	var kept []po.LikeRecord
+	if err := db.Unscoped().Order("id ASC").Find(&kept).Error; err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		id       int64
+		deleted  bool
+		rewarded bool
+	}{
+		{rows[0].ID, false, true},
+		{rows[3].ID, false, true},
+		{rows[5].ID, true, false},
+		{rows[6].ID, false, true},
+	}
+	if len(kept) != len(want) {
+		t.Fatalf("合并后 %d 条记录, want %d", len(kept), len(want))
+	}
+	for i, w := range want {
+		if kept[i].ID != w.id || kept[i].DeletedAt.Valid != w.deleted || kept[i].Rewarded != w.rewarded {
+			t.Errorf("第 %d 条 = {id:%d deleted:%v rewarded:%v}, want %+v",
+				i, kept[i].ID, kept[i].DeletedAt.Valid, kept[i].Rewarded, w)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package initialize
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"gorm.io/gorm"
+)
+
+// defaultHealthCheckInterval 未配置 health.check_interval 时检查数据库连接的间隔
+const defaultHealthCheckInterval = 5 * time.Second
+
+// HealthChecker 根据数据库连接状态维护 grpc.health.v1 服务的状态
+type HealthChecker struct {
+	server     *health.Server
+	db         *gorm.DB
+	migrateErr error
+	services   []string
+}
+
+// InitHealth 注册 grpc.health.v1 服务，services 为需要报告状态的服务名，空字符串表示整体状态
+// 在第一次检查完成前所有服务都是 NOT_SERVING
+func InitHealth(grpcServer *grpc.Server, db *gorm.DB, migrateErr error, services ...string) *HealthChecker {
+	checker := &HealthChecker{
+		server:     health.NewServer(),
+		db:         db,
+		migrateErr: migrateErr,
+		services:   append([]string{""}, services...),
+	}
+	checker.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, checker.server)
+	return checker
+}
+
+// Run 定期检查数据库连接直到 ctx 结束，迁移失败时始终报告 NOT_SERVING
+func (c *HealthChecker) Run(ctx context.Context) {
+	interval := viper.GetDuration("health.check_interval")
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	serving := false
+	for {
+		ok := c.check(ctx)
+		if ok != serving {
+			serving = ok
+			if serving {
+				c.setStatus(healthpb.HealthCheckResponse_SERVING)
+				slog.Info("数据库连接正常，服务可用")
+			} else {
+				c.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+				slog.Warn("数据库不可用，服务标记为 NOT_SERVING")
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown 将所有服务标记为 NOT_SERVING 并忽略之后的状态更新，在 GracefulStop 之前调用
+func (c *HealthChecker) Shutdown() {
+	c.server.Shutdown()
+}
+
+// check 检查迁移结果和数据库连接
+func (c *HealthChecker) check(ctx context.Context) bool {
+	if c.migrateErr != nil || c.db == nil {
+		return false
+	}
+	sqlDB, err := c.db.DB()
+	if err != nil {
+		return false
+	}
+	pingCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if err := sqlDB.PingContext(pingCtx); err != nil {
+		slog.Warn("数据库连接检查失败", "error", err)
+		return false
+	}
+	return true
+}
+
+func (c *HealthChecker) setStatus(servingStatus healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range c.services {
+		c.server.SetServingStatus(service, servingStatus)
+	}
+}
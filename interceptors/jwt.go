@@ -2,8 +2,10 @@ package interceptors
 
 import (
 	"context"
+	"strconv"
 	"strings"
 
+	"github.com/trancecho/mundo-points-system/pkg/tracing"
 	"github.com/trancecho/mundo-points-system/pkg/utils"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -11,40 +13,102 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// 服务间调用使用的 metadata
+const (
+	// ServiceTokenHeader 服务间调用凭证
+	ServiceTokenHeader = "x-service-token"
+	// OnBehalfOfHeader 服务代为操作的用户ID
+	OnBehalfOfHeader = "x-on-behalf-of"
+)
+
 // JWTInterceptor 创建一个用于验证JWT的拦截器
+// 携带 x-service-token 的请求按服务间调用处理，否则按用户 token 处理
 func JWTInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		// 从元数据中获取token
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Errorf(codes.Unauthenticated, "metadata不存在")
+		if isPublicMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		// 认证单独记录一个 span，便于区分耗时在认证还是业务处理
+		spanCtx, span := tracing.Start(ctx, "interceptors.Authenticate")
+		claims, service, err := authenticate(spanCtx)
+		tracing.End(span, err)
+		if err != nil {
+			return nil, err
+		}
+
+		// 将claims的具体字段添加到上下文中
+		newCtx := context.WithValue(ctx, "claims", claims)
+		newCtx = context.WithValue(newCtx, "user_id", claims.UserID)
+		newCtx = context.WithValue(newCtx, "username", claims.Username)
+		newCtx = context.WithValue(newCtx, "role", claims.Role)
+		if service != "" {
+			newCtx = context.WithValue(newCtx, "service", service)
 		}
+		setLogUser(ctx, claims.UserID, service)
+
+		// 继续处理请求
+		return handler(newCtx, req)
+	}
+}
+
+// authenticate 从 metadata 中解析用户 token 或服务间调用凭证
+func authenticate(ctx context.Context) (*utils.Claims, string, error) {
+	// 从元数据中获取token
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, "", status.Errorf(codes.Unauthenticated, "metadata不存在")
+	}
 
+	var claims *utils.Claims
+	var service string
+	if serviceToken := md.Get(ServiceTokenHeader); len(serviceToken) > 0 {
+		var err error
+		claims, service, err = parseServiceCredential(serviceToken[0], md.Get(OnBehalfOfHeader))
+		if err != nil {
+			return nil, "", err
+		}
+	} else {
 		// 获取Authorization header
 		authorization := md.Get("authorization")
 		if len(authorization) == 0 {
-			return nil, status.Errorf(codes.Unauthenticated, "authorization header不存在")
+			return nil, "", status.Errorf(codes.Unauthenticated, "authorization header不存在")
 		}
 
 		// 提取token
 		token := strings.TrimPrefix(authorization[0], "Bearer ")
 		if token == authorization[0] {
-			return nil, status.Errorf(codes.Unauthenticated, "token格式错误")
+			return nil, "", status.Errorf(codes.Unauthenticated, "token格式错误")
 		}
 
 		// 验证token
-		claims, err := utils.ParseToken("mundo", token)
+		var err error
+		claims, err = utils.ParseToken("mundo", token)
 		if err != nil {
-			return nil, status.Errorf(codes.Unauthenticated, "token无效: %v", err)
+			return nil, "", status.Errorf(codes.Unauthenticated, "token无效: %v", err)
 		}
+	}
 
-		// 将claims的具体字段添加到上下文中
-		newCtx := context.WithValue(ctx, "claims", claims)
-		newCtx = context.WithValue(newCtx, "user_id", claims.UserID)
-		newCtx = context.WithValue(newCtx, "username", claims.Username)
-		newCtx = context.WithValue(newCtx, "role", claims.Role)
+	return claims, service, nil
+}
 
-		// 继续处理请求
-		return handler(newCtx, req)
+// parseServiceCredential 验证服务间调用凭证，返回以服务角色代为操作指定用户的 claims
+// 没有指定代为操作的用户时 UserID 为 0，只能调用不依赖当前用户的接口（见 actsOnCaller）
+func parseServiceCredential(token string, onBehalfOf []string) (*utils.Claims, string, error) {
+	service, err := utils.ParseServiceToken(token)
+	if err != nil {
+		return nil, "", status.Errorf(codes.Unauthenticated, "服务凭证无效: %v", err)
+	}
+
+	claims := &utils.Claims{
+		Role: utils.RoleService,
 	}
+	if len(onBehalfOf) > 0 && onBehalfOf[0] != "" {
+		claims.UserID, err = strconv.ParseInt(onBehalfOf[0], 10, 64)
+		if err != nil {
+			return nil, "", status.Errorf(codes.InvalidArgument, "无效的代操作用户ID")
+		}
+	}
+
+	return claims, service, nil
 }
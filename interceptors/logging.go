@@ -0,0 +1,91 @@
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDHeader 请求ID，调用方没有传时由服务端生成，并在响应 header 中返回
+const RequestIDHeader = "x-request-id"
+
+// requestLog 一次请求的日志字段，由后续拦截器补充
+type requestLog struct {
+	userID  int64
+	service string
+}
+
+type requestLogKey struct{}
+
+// setLogUser 记录当前请求的用户，JWTInterceptor 验证通过后调用
+func setLogUser(ctx context.Context, userID int64, service string) {
+	if entry, ok := ctx.Value(requestLogKey{}).(*requestLog); ok {
+		entry.userID = userID
+		entry.service = service
+	}
+}
+
+// LoggingInterceptor 记录每个请求的方法、用户、耗时和状态码，需要放在拦截器链的最外层
+func LoggingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		requestID := ""
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if ids := md.Get(RequestIDHeader); len(ids) > 0 {
+				requestID = ids[0]
+			}
+		}
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID))
+
+		entry := &requestLog{}
+		ctx = context.WithValue(ctx, requestLogKey{}, entry)
+		ctx = context.WithValue(ctx, "request_id", requestID)
+
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		attrs := []any{
+			slog.String("request_id", requestID),
+			slog.String("method", info.FullMethod),
+			slog.Int64("user_id", entry.userID),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("code", code.String()),
+		}
+		if entry.service != "" {
+			attrs = append(attrs, slog.String("service", entry.service))
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+		}
+		level := logLevel(code)
+		// 探活请求很频繁，只在 debug 级别记录
+		if isPublicMethod(info.FullMethod) && level == slog.LevelInfo {
+			level = slog.LevelDebug
+		}
+		slog.Log(ctx, level, "grpc request", attrs...)
+
+		return resp, err
+	}
+}
+
+// logLevel 服务端错误记为 error，客户端错误记为 warn
+func logLevel(code codes.Code) slog.Level {
+	switch code {
+	case codes.OK:
+		return slog.LevelInfo
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable, codes.DeadlineExceeded:
+		return slog.LevelError
+	default:
+		return slog.LevelWarn
+	}
+}
@@ -0,0 +1,20 @@
+package interceptors
+
+import (
+	"context"
+	"time"
+
+	"github.com/trancecho/mundo-points-system/pkg/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// MetricsInterceptor 按方法统计请求数、状态码和耗时，放在 JWT 拦截器之前以便统计认证失败的请求
+func MetricsInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		metrics.ObserveRequest(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return resp, err
+	}
+}
@@ -0,0 +1,157 @@
+package interceptors
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/spf13/viper"
+	"github.com/trancecho/mundo-points-system/pkg/utils"
+	v1 "github.com/trancecho/mundo-points-system/proto/point/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MethodPolicy 方法级权限策略，Method 为 gRPC 方法全名，Roles 为允许调用的角色
+type MethodPolicy struct {
+	Method string   `mapstructure:"method"`
+	Roles  []string `mapstructure:"roles"`
+}
+
+// RoleAuthenticated 策略中表示所有登录用户（包括服务账号）的角色
+const RoleAuthenticated = "authenticated"
+
+// PrivilegedSuffix 特权操作的策略名后缀，如查询其他用户的记录
+// 请求是特权操作时，除了方法本身的策略，还要满足 方法全名+PrivilegedSuffix 的策略
+const PrivilegedSuffix = ":privileged"
+
+// defaultPolicies 内置策略，没有策略的方法任何人都不能调用，新增方法时需要在这里加上策略
+func defaultPolicies() map[string][]string {
+	return map[string][]string{
+		v1.UserService_Sign_FullMethodName:                                {RoleAuthenticated},
+		v1.UserService_UpdatePointsAndExperience_FullMethodName:           {utils.RoleAdmin, utils.RoleService},
+		v1.UserService_EnsureUser_FullMethodName:                          {RoleAuthenticated},
+		v1.UserService_EnsureUser_FullMethodName + PrivilegedSuffix:       {utils.RoleAdmin, utils.RoleService},
+		v1.UserService_GetUserInfo_FullMethodName:                         {RoleAuthenticated},
+		v1.UserService_BatchGetUserInfo_FullMethodName:                    {RoleAuthenticated},
+		v1.UserService_ProcessLike_FullMethodName:                         {RoleAuthenticated},
+		v1.UserService_UnprocessLike_FullMethodName:                       {RoleAuthenticated},
+		v1.UserService_GetAdminStats_FullMethodName:                       {utils.RoleAdmin},
+		v1.UserService_ListPointRecords_FullMethodName:                    {RoleAuthenticated},
+		v1.UserService_ListPointRecords_FullMethodName + PrivilegedSuffix: {utils.RoleAdmin},
+		v1.UserService_ReversePointRecord_FullMethodName:                  {utils.RoleAdmin, utils.RoleService},
+		v1.UserService_ListLevels_FullMethodName:                          {RoleAuthenticated},
+		v1.UserService_GetLeaderboard_FullMethodName:                      {RoleAuthenticated},
+		v1.UserService_TransferPoints_FullMethodName:                      {RoleAuthenticated},
+		v1.UserService_ListRewardItems_FullMethodName:                     {RoleAuthenticated},
+		v1.UserService_ListRewardItems_FullMethodName + PrivilegedSuffix:  {utils.RoleAdmin},
+		v1.UserService_RedeemItem_FullMethodName:                          {RoleAuthenticated},
+		v1.UserService_CreateRewardItem_FullMethodName:                    {utils.RoleAdmin},
+		v1.UserService_UpdateRewardItem_FullMethodName:                    {utils.RoleAdmin},
+		v1.UserService_ListRedeemOrders_FullMethodName:                    {utils.RoleAdmin},
+		v1.UserService_AdminAdjustPoints_FullMethodName:                   {utils.RoleAdmin},
+		v1.UserService_ListAdminAudit_FullMethodName:                      {utils.RoleAdmin},
+	}
+}
+
+// isPrivileged 判断请求是否为方法中的特权操作
+func isPrivileged(req interface{}, claims *utils.Claims) bool {
+	self := strconv.FormatInt(claims.UserID, 10)
+	switch r := req.(type) {
+	case *v1.EnsureUserRequest:
+		// 为其他用户创建
+		return r.UserId != "" && r.UserId != self
+	case *v1.ListPointRecordsRequest:
+		// 查询其他用户的记录
+		return r.UserId != "" && r.UserId != self
+	case *v1.ListRewardItemsRequest:
+		// 查看未上架的商品
+		return r.IncludeUnavailable
+	}
+	return false
+}
+
+// actsOnCaller 判断请求是否操作调用方自己的账号，没有代为操作用户的服务账号不能调用
+func actsOnCaller(req interface{}) bool {
+	switch r := req.(type) {
+	case *v1.SignRequest, *v1.LikeRequest, *v1.TransferPointsRequest, *v1.RedeemItemRequest:
+		return true
+	case *v1.UpdatePointsRequest:
+		return r.UserId == ""
+	case *v1.EnsureUserRequest:
+		return r.UserId == ""
+	case *v1.ListPointRecordsRequest:
+		return r.UserId == ""
+	}
+	return false
+}
+
+// LoadPolicies 读取内置策略，并用配置项 authz.policies 覆盖同名方法的策略
+func LoadPolicies() (map[string][]string, error) {
+	policies := defaultPolicies()
+	var configured []MethodPolicy
+	if err := viper.UnmarshalKey("authz.policies", &configured); err != nil {
+		return nil, err
+	}
+	for _, policy := range configured {
+		policies[policy.Method] = policy.Roles
+	}
+	return policies, nil
+}
+
+// AuthzPolicy 方法级权限策略集合，支持运行时替换
+type AuthzPolicy struct {
+	policies atomic.Pointer[map[string][]string]
+}
+
+// NewAuthzPolicy 创建权限策略集合
+func NewAuthzPolicy(policies map[string][]string) *AuthzPolicy {
+	p := &AuthzPolicy{}
+	p.policies.Store(&policies)
+	return p
+}
+
+// Reload 从配置重新加载策略，加载失败时保留原策略
+func (p *AuthzPolicy) Reload() error {
+	policies, err := LoadPolicies()
+	if err != nil {
+		return err
+	}
+	p.policies.Store(&policies)
+	return nil
+}
+
+// Allowed 判断角色是否可以调用方法，没有策略的方法一律拒绝
+func (p *AuthzPolicy) Allowed(method string, role string) bool {
+	roles := (*p.policies.Load())[method]
+	return slices.Contains(roles, RoleAuthenticated) || slices.Contains(roles, role)
+}
+
+// Interceptor 创建按方法检查角色的拦截器，需要放在 JWTInterceptor 之后
+func (p *AuthzPolicy) Interceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if isPublicMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		claims, ok := ctx.Value("claims").(*utils.Claims)
+		if !ok {
+			return nil, status.Errorf(codes.Unauthenticated, "failed to get user claims from context")
+		}
+
+		if !p.Allowed(info.FullMethod, claims.Role) {
+			return nil, status.Errorf(codes.PermissionDenied, "用户无权限")
+		}
+		if isPrivileged(req, claims) && !p.Allowed(info.FullMethod+PrivilegedSuffix, claims.Role) {
+			return nil, status.Errorf(codes.PermissionDenied, "用户无权限")
+		}
+		// 服务账号没有通过 x-on-behalf-of 指定用户时 UserID 为 0，不能当作用户 0 操作
+		if claims.UserID <= 0 && actsOnCaller(req) {
+			return nil, status.Errorf(codes.PermissionDenied, "服务调用需要通过 x-on-behalf-of 指定用户")
+		}
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,45 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/trancecho/mundo-points-system/pkg/utils"
+	v1 "github.com/trancecho/mundo-points-system/proto/point/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServiceWithoutUserCannotActOnCaller(t *testing.T) {
+	interceptor := NewAuthzPolicy(defaultPolicies()).Interceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+
+	tests := []struct {
+		name   string
+		method string
+		req    interface{}
+		userID int64
+		want   codes.Code
+	}{
+		{name: "点赞", method: v1.UserService_ProcessLike_FullMethodName, req: &v1.LikeRequest{}, want: codes.PermissionDenied},
+		{name: "取消点赞", method: v1.UserService_UnprocessLike_FullMethodName, req: &v1.LikeRequest{}, want: codes.PermissionDenied},
+		{name: "签到", method: v1.UserService_Sign_FullMethodName, req: &v1.SignRequest{}, want: codes.PermissionDenied},
+		{name: "转账", method: v1.UserService_TransferPoints_FullMethodName, req: &v1.TransferPointsRequest{}, want: codes.PermissionDenied},
+		{name: "兑换", method: v1.UserService_RedeemItem_FullMethodName, req: &v1.RedeemItemRequest{}, want: codes.PermissionDenied},
+		{name: "变更积分未指定用户", method: v1.UserService_UpdatePointsAndExperience_FullMethodName, req: &v1.UpdatePointsRequest{}, want: codes.PermissionDenied},
+		{name: "变更指定用户的积分", method: v1.UserService_UpdatePointsAndExperience_FullMethodName, req: &v1.UpdatePointsRequest{UserId: "1"}, want: codes.OK},
+		{name: "为指定用户创建", method: v1.UserService_EnsureUser_FullMethodName, req: &v1.EnsureUserRequest{UserId: "1"}, want: codes.OK},
+		{name: "不依赖当前用户的接口", method: v1.UserService_ListLevels_FullMethodName, req: &v1.ListLevelsRequest{}, want: codes.OK},
+		{name: "代为操作用户点赞", method: v1.UserService_ProcessLike_FullMethodName, req: &v1.LikeRequest{}, userID: 1, want: codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), "claims", &utils.Claims{UserID: tt.userID, Role: utils.RoleService})
+			_, err := interceptor(ctx, tt.req, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("返回 %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
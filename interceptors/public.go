@@ -0,0 +1,18 @@
+package interceptors
+
+import "strings"
+
+// publicMethodPrefixes 不需要登录即可调用的方法，如探活
+var publicMethodPrefixes = []string{
+	"/grpc.health.v1.Health/",
+}
+
+// isPublicMethod 判断方法是否不需要登录
+func isPublicMethod(fullMethod string) bool {
+	for _, prefix := range publicMethodPrefixes {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,22 +1,31 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	gw_sdk "github.com/trancecho/mundo-gateway-sdk"
 	"github.com/trancecho/mundo-points-system/config"
 	"github.com/trancecho/mundo-points-system/domain"
 	"github.com/trancecho/mundo-points-system/interceptors"
+	"github.com/trancecho/mundo-points-system/pkg/logger"
+	"github.com/trancecho/mundo-points-system/pkg/tracing"
 	"github.com/trancecho/mundo-points-system/pkg/utils"
 	"github.com/trancecho/mundo-points-system/po/repository"
-	"log"
+	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/viper"
 	"github.com/trancecho/mundo-points-system/initialize"
 	pb "github.com/trancecho/mundo-points-system/proto/point/v1"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
@@ -24,53 +33,157 @@ import (
 func main() {
 	// 初始化配置
 	config.InitConfig()
+	// 初始化日志，开发模式使用彩色控制台输出
+	logger.Init(config.Mode == "dev")
+	// 初始化链路追踪，需在数据库和 gRPC 服务之前
+	shutdownTracing, err := tracing.Init()
+	if err != nil {
+		logger.Fatal("初始化链路追踪失败", "error", err)
+	}
 	// 顺序不能错
 	utils.InitSecret()
 
-	// 初始化数据库连接
-	db := initialize.InitDB()
+	// 初始化数据库连接，连接或迁移失败时服务仍然启动，但健康检查报告不可用
+	db, dbErr := initialize.InitDB()
 
 	// 启动 gRPC 服务器
 	lis, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", viper.GetInt("grpc.port")))
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		logger.Fatal("Failed to listen", "error", err)
+	}
+	// 等级曲线
+	levelCurve, err := domain.LoadLevelCurve()
+	if err != nil {
+		logger.Fatal("加载等级曲线失败", "error", err)
 	}
+	levels := domain.NewLevelEngine(levelCurve)
 	//创建实现
-	userRepo := repository.NewUserRepository(db)
+	userRepo := repository.NewUserRepository(db, levels)
 	pointRepo := repository.NewPointRepository(db)
 	statRepo := repository.NewStatisticsRepository(db)
-	// 创建带有JWT拦截器的gRPC服务器
+	shopRepo := repository.NewShopRepository(db)
+	// 签到奖励规则，配置文件变更后自动重新加载
+	signRules, err := domain.LoadSignRewardRules()
+	if err != nil {
+		logger.Fatal("加载签到奖励规则失败", "error", err)
+	}
+	signRewards := domain.NewSignRewardEngine(signRules)
+	// 等级重算，数据库不可用或表结构迁移失败时不执行，避免在不完整的表上批量更新
+	recalculator := domain.NewLevelRecalculator(userRepo)
+	recalculateLevels := func() {
+		if dbErr != nil {
+			slog.Warn("数据库不可用或迁移失败，跳过重算用户等级", "error", dbErr)
+			return
+		}
+		recalculator.Trigger()
+	}
+	config.OnReload(func() {
+		if err := signRewards.Reload(); err != nil {
+			slog.Error("重新加载签到奖励规则失败，继续使用原规则", "error", err)
+			return
+		}
+		slog.Info("签到奖励规则已重新加载")
+	})
+	config.OnReload(func() {
+		changed, err := levels.Reload()
+		if err != nil {
+			slog.Error("重新加载等级曲线失败，继续使用原曲线", "error", err)
+			return
+		}
+		// 等级门槛变化后需要重算所有用户的等级
+		if changed {
+			recalculateLevels()
+		}
+	})
+	// 启动时按当前曲线校正一次，覆盖停机期间修改曲线的情况
+	recalculateLevels()
+	// 积分有效期，只影响之后获得的积分
+	repository.SetPointLifetime(domain.PointLifetimeMonths())
+	config.OnReload(func() {
+		repository.SetPointLifetime(domain.PointLifetimeMonths())
+	})
+	// 方法级权限策略
+	policies, err := interceptors.LoadPolicies()
+	if err != nil {
+		logger.Fatal("加载权限策略失败", "error", err)
+	}
+	authz := interceptors.NewAuthzPolicy(policies)
+	config.OnReload(func() {
+		if err := authz.Reload(); err != nil {
+			slog.Error("重新加载权限策略失败，继续使用原策略", "error", err)
+		}
+	})
+	config.OnReload(func() {
+		if err := utils.ReloadMundoKeys(); err != nil {
+			slog.Error("重新加载JWKS失败，继续使用原公钥", "error", err)
+		}
+	})
+	config.WatchConfig()
+	// 创建带有追踪、日志、JWT和权限拦截器的gRPC服务器
+	// 追踪使用 stats handler，从 metadata 中提取上游的 trace 上下文，健康检查不生成 span
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(interceptors.JWTInterceptor()),
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))),
+		grpc.ChainUnaryInterceptor(
+			interceptors.LoggingInterceptor(),
+			interceptors.MetricsInterceptor(),
+			interceptors.JWTInterceptor(),
+			authz.Interceptor(),
+		),
 	)
-	pb.RegisterUserServiceServer(grpcServer, domain.NewUserService(userRepo, pointRepo, statRepo))
+	// 连接数据库失败时没有可用的连接，不注册业务服务，只通过健康检查报告不可用
+	if db != nil {
+		pb.RegisterUserServiceServer(grpcServer, domain.NewUserService(userRepo, pointRepo, statRepo, shopRepo, signRewards, levels))
+	}
+
+	// 注册健康检查服务，数据库不可用或迁移失败时报告 NOT_SERVING
+	healthChecker := initialize.InitHealth(grpcServer, db, dbErr, pb.UserService_ServiceDesc.ServiceName)
+	healthCtx, stopHealth := context.WithCancel(context.Background())
+	go healthChecker.Run(healthCtx)
+	// 定期清理过期积分，与等级重算一样在数据库不可用或迁移失败时不执行
+	sweepCtx, stopSweep := context.WithCancel(context.Background())
+	if dbErr == nil {
+		go domain.NewExpirySweeper(pointRepo).Run(sweepCtx)
+	} else {
+		slog.Warn("数据库不可用或迁移失败，不清理过期积分", "error", dbErr)
+	}
 
 	// 注册反射服务
 	reflection.Register(grpcServer)
 
 	// 打印已注册的服务和方法
 	serviceInfo := grpcServer.GetServiceInfo()
-	log.Println("注册的gRPC服务列表:")
 	for svc, info := range serviceInfo {
-		log.Printf("服务: %s", svc)
+		methods := make([]string, 0, len(info.Methods))
 		for _, method := range info.Methods {
-			log.Printf("  - 方法: %s", method.Name)
+			methods = append(methods, method.Name)
 		}
+		slog.Info("注册的gRPC服务", "service", svc, "methods", methods)
 	}
 
 	// 启动 gRPC 服务器
 	go func() {
-		log.Printf("Starting gRPC server on port %d", viper.GetInt("grpc.port"))
+		slog.Info("Starting gRPC server", "port", viper.GetInt("grpc.port"))
 		if err := grpcServer.Serve(lis); err != nil {
-			log.Fatalf("Failed to serve: %v", err)
+			logger.Fatal("Failed to serve", "error", err)
+		}
+	}()
+	// 启动 Prometheus 指标服务
+	metricsServer := &http.Server{
+		Addr:    fmt.Sprintf("0.0.0.0:%d", metricsPort()),
+		Handler: metricsHandler(),
+	}
+	go func() {
+		slog.Info("Starting metrics server", "port", metricsPort())
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Failed to serve metrics", "error", err)
 		}
 	}()
 	gatewaySDK := gw_sdk.NewGatewaySDK(viper.GetString("service.name"), viper.GetString("gateway.mundo.myaddr"), "grpc", viper.GetString("gateway.mundo.url"))
 	// 自动注册 gRPC 路由到网关
 	if err = gatewaySDK.AutoRegisterGRPCRoutes(grpcServer, "points_system"); err != nil {
-		log.Printf("无法自动注册gRPC路由: %v", err)
+		slog.Error("无法自动注册gRPC路由", "error", err)
 	} else {
-		log.Println("所有gRPC路由已成功自动注册")
+		slog.Info("所有gRPC路由已成功自动注册")
 	}
 
 	// 优雅关闭
@@ -78,8 +191,31 @@ func main() {
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 	<-sigChan
 
-	// 关闭服务
-	//cancel()
+	// 关闭服务，先让探活失败，负载均衡不再转发新请求
+	healthChecker.Shutdown()
+	stopHealth()
 	grpcServer.GracefulStop()
-	log.Println("Server shutdown gracefully")
+	stopSweep()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = metricsServer.Shutdown(shutdownCtx)
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("关闭链路追踪失败", "error", err)
+	}
+	slog.Info("Server shutdown gracefully")
+}
+
+// metricsPort 指标服务端口，未配置 metrics.port 时使用 9090
+func metricsPort() int {
+	if viper.IsSet("metrics.port") {
+		return viper.GetInt("metrics.port")
+	}
+	return 9090
+}
+
+// metricsHandler 只暴露 /metrics
+func metricsHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	return mux
 }
@@ -0,0 +1,140 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+
+	"github.com/trancecho/mundo-points-system/pkg/colorful"
+)
+
+// ConsoleHandler 开发模式使用的彩色控制台日志，按级别着色，字段以 key=value 形式输出
+type ConsoleHandler struct {
+	opts   slog.HandlerOptions
+	attrs  []slog.Attr
+	groups []string
+	mu     *sync.Mutex
+	w      io.Writer
+}
+
+// NewConsoleHandler 创建彩色控制台日志
+func NewConsoleHandler(w io.Writer, opts *slog.HandlerOptions) *ConsoleHandler {
+	h := &ConsoleHandler{mu: &sync.Mutex{}, w: w}
+	if opts != nil {
+		h.opts = *opts
+	}
+	return h
+}
+
+// Enabled 实现 slog.Handler
+func (h *ConsoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.opts.Level != nil {
+		minLevel = h.opts.Level.Level()
+	}
+	return level >= minLevel
+}
+
+// Handle 实现 slog.Handler
+func (h *ConsoleHandler) Handle(_ context.Context, r slog.Record) error {
+	var buf bytes.Buffer
+	buf.WriteString(r.Time.Format("2006-01-02 15:04:05.000"))
+	buf.WriteByte(' ')
+	buf.WriteString(levelColor(r.Level)(fmt.Sprintf("%-5s", r.Level.String())))
+	buf.WriteByte(' ')
+	buf.WriteString(r.Message)
+
+	for _, a := range h.attrs {
+		h.appendAttr(&buf, nil, a)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		h.appendAttr(&buf, h.groups, a)
+		return true
+	})
+	buf.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.w.Write(buf.Bytes())
+	return err
+}
+
+// appendAttr 以 group.key=value 形式追加字段
+func (h *ConsoleHandler) appendAttr(buf *bytes.Buffer, groups []string, a slog.Attr) {
+	if h.opts.ReplaceAttr != nil && a.Value.Kind() != slog.KindGroup {
+		a = h.opts.ReplaceAttr(groups, a)
+	}
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			groups = append(append([]string(nil), groups...), a.Key)
+		}
+		for _, ga := range a.Value.Group() {
+			h.appendAttr(buf, groups, ga)
+		}
+		return
+	}
+
+	buf.WriteByte(' ')
+	for _, g := range groups {
+		buf.WriteString(g)
+		buf.WriteByte('.')
+	}
+	buf.WriteString(colorful.Cyan(a.Key))
+	buf.WriteByte('=')
+	buf.WriteString(a.Value.String())
+}
+
+// WithAttrs 实现 slog.Handler
+func (h *ConsoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	// 预先带上当前分组，输出时不再拼接分组前缀
+	grouped := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		if len(h.groups) > 0 {
+			a = groupAttr(h.groups, a)
+		}
+		grouped = append(grouped, a)
+	}
+	h2.attrs = append(append([]slog.Attr(nil), h.attrs...), grouped...)
+	return &h2
+}
+
+// WithGroup 实现 slog.Handler
+func (h *ConsoleHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.groups = append(append([]string(nil), h.groups...), name)
+	return &h2
+}
+
+// groupAttr 把字段包进多层分组
+func groupAttr(groups []string, a slog.Attr) slog.Attr {
+	for i := len(groups) - 1; i >= 0; i-- {
+		a = slog.Attr{Key: groups[i], Value: slog.GroupValue(a)}
+	}
+	return a
+}
+
+// levelColor 不同级别使用不同颜色
+func levelColor(level slog.Level) func(string) string {
+	switch {
+	case level >= slog.LevelError:
+		return colorful.Red
+	case level >= slog.LevelWarn:
+		return colorful.Yellow
+	case level >= slog.LevelInfo:
+		return colorful.Green
+	default:
+		return colorful.Blue
+	}
+}
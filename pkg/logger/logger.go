@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// sensitiveKeys 字段名包含这些词时日志中只输出 [REDACTED]
+var sensitiveKeys = []string{"authorization", "token", "secret", "password", "cookie"}
+
+// Init 按配置初始化全局日志
+// log.format 可选 json、text、console，未配置时开发模式使用彩色 console，其他模式使用 json
+// log.level 可选 debug、info、warn、error，默认 info
+func Init(dev bool) {
+	level := new(slog.LevelVar)
+	if err := level.UnmarshalText([]byte(viper.GetString("log.level"))); err != nil {
+		level.Set(slog.LevelInfo)
+	}
+
+	format := viper.GetString("log.format")
+	if format == "" {
+		format = "json"
+		if dev {
+			format = "console"
+		}
+	}
+
+	opts := &slog.HandlerOptions{Level: level, ReplaceAttr: Redact}
+	var handler slog.Handler
+	switch format {
+	case "console":
+		handler = NewConsoleHandler(os.Stdout, opts)
+	case "text":
+		handler = slog.NewTextHandler(os.Stdout, opts)
+	default:
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	}
+	slog.SetDefault(slog.New(handler))
+}
+
+// Redact 隐藏敏感字段的值，可作为 slog.HandlerOptions.ReplaceAttr 使用
+func Redact(groups []string, a slog.Attr) slog.Attr {
+	key := strings.ToLower(a.Key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return slog.String(a.Key, "[REDACTED]")
+		}
+	}
+	return a
+}
+
+// Fatal 记录错误日志后退出进程
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "mundo_points"
+
+var (
+	grpcHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_server_handled_total",
+		Help:      "按方法和状态码统计的 gRPC 请求数",
+	}, []string{"method", "code"})
+
+	grpcLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_server_handling_seconds",
+		Help:      "按方法统计的 gRPC 请求耗时",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	pointsGranted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "points_granted_total",
+		Help:      "按原因统计发放的积分",
+	}, []string{"reason"})
+
+	pointsSpent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "points_spent_total",
+		Help:      "按原因统计扣除的积分",
+	}, []string{"reason"})
+
+	signIns = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sign_ins_total",
+		Help:      "签到次数，按天统计使用 increase(...[1d])",
+	})
+
+	likes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "likes_processed_total",
+		Help:      "处理的点赞和取消点赞次数",
+	}, []string{"action"})
+
+	levelChanges = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "level_changes_total",
+		Help:      "用户等级变化次数",
+	}, []string{"direction"})
+
+	pointsInsufficient = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "points_insufficient_total",
+		Help:      "因积分不足被拒绝的请求数",
+	}, []string{"method"})
+)
+
+// ObserveRequest 记录一次 gRPC 请求
+func ObserveRequest(method string, code string, latency time.Duration) {
+	grpcHandled.WithLabelValues(method, code).Inc()
+	grpcLatency.WithLabelValues(method).Observe(latency.Seconds())
+}
+
+// ReasonOther 不在已知原因集合内的积分变更使用的标签
+const ReasonOther = "other"
+
+// ObservePointChange 记录一次已提交的积分变更
+// reason 作为标签值，调用方需要先把它归入有限的集合，避免业务方传入的原因造成标签基数失控
+func ObservePointChange(reason string, points int64) {
+	if points > 0 {
+		pointsGranted.WithLabelValues(reason).Add(float64(points))
+	} else if points < 0 {
+		pointsSpent.WithLabelValues(reason).Add(float64(-points))
+	}
+}
+
+// ObserveSignIn 记录一次成功签到
+func ObserveSignIn() {
+	signIns.Inc()
+}
+
+// ObserveLike 记录一次点赞（like）或取消点赞（unlike）
+func ObserveLike(action string) {
+	likes.WithLabelValues(action).Inc()
+}
+
+// ObserveLevelChange 记录一次等级变化
+func ObserveLevelChange(oldLevel int, newLevel int) {
+	switch {
+	case newLevel > oldLevel:
+		levelChanges.WithLabelValues("up").Inc()
+	case newLevel < oldLevel:
+		levelChanges.WithLabelValues("down").Inc()
+	}
+}
+
+// ObservePointsInsufficient 记录一次积分不足的拒绝
+func ObservePointsInsufficient(method string) {
+	pointsInsufficient.WithLabelValues(method).Inc()
+}
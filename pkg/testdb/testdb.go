@@ -0,0 +1,74 @@
+// Package testdb 为测试提供迁移好表结构的数据库
+package testdb
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/trancecho/mundo-points-system/initialize"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// DSNEnv 设置后测试使用该 MySQL 数据库，每个测试开始前会删除并重建所有表，不要指向正式库
+const DSNEnv = "MUNDO_TEST_MYSQL_DSN"
+
+// tables 需要在测试前清空的表，顺序与迁移无关
+var tables = []string{
+	"user_infos", "like_records", "point_records", "level_change_records",
+	"reward_items", "redeem_orders", "admin_audit_records",
+}
+
+// Open 返回空数据库，设置了 MUNDO_TEST_MYSQL_DSN 时使用 MySQL，否则使用内存 SQLite
+// SQLite 只有一个连接，也不支持 SELECT ... FOR UPDATE，并发相关的测试需要通过 RequireMySQL 跳过
+func Open(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv(DSNEnv)
+	var dialector gorm.Dialector
+	if dsn != "" {
+		dialector = mysql.Open(dsn)
+	} else {
+		// 每个测试使用独立的内存库
+		name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+		dialector = sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", name))
+	}
+	db, err := gorm.Open(dialector, &gorm.Config{
+		TranslateError: true,
+		Logger:         logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("连接测试数据库失败: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("获取数据库连接失败: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	if dsn == "" {
+		sqlDB.SetMaxOpenConns(1)
+	} else {
+		for _, table := range tables {
+			if err := db.Migrator().DropTable(table); err != nil {
+				t.Fatalf("删除表 %s 失败: %v", table, err)
+			}
+		}
+	}
+	if err := initialize.Migrate(db); err != nil {
+		t.Fatalf("迁移表结构失败: %v", err)
+	}
+	return db
+}
+
+// RequireMySQL 没有配置 MySQL 时跳过测试
+func RequireMySQL(t testing.TB) {
+	t.Helper()
+	if os.Getenv(DSNEnv) == "" {
+		t.Skipf("需要设置 %s 指向 MySQL 测试库", DSNEnv)
+	}
+}
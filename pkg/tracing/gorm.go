@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// gormSpanKey 当前语句的 span 在 gorm 实例中的键
+const gormSpanKey = "tracing:span"
+
+// gormSpan 保存语句的 span 和创建 span 前的上下文，结束后还原上下文，避免同一 Statement 上的 span 层层嵌套
+type gormSpan struct {
+	span   trace.Span
+	parent context.Context
+}
+
+// GormPlugin 为每次 GORM 数据库调用创建 span，span 挂在 WithContext 传入的上下文下
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string {
+	return "tracing"
+}
+
+// Initialize 在各类回调前后注册创建和结束 span 的钩子
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("tracing:before_create", gormBefore("gorm.create")),
+		cb.Create().After("gorm:create").Register("tracing:after_create", gormAfter),
+		cb.Query().Before("gorm:query").Register("tracing:before_query", gormBefore("gorm.query")),
+		cb.Query().After("gorm:query").Register("tracing:after_query", gormAfter),
+		cb.Update().Before("gorm:update").Register("tracing:before_update", gormBefore("gorm.update")),
+		cb.Update().After("gorm:update").Register("tracing:after_update", gormAfter),
+		cb.Delete().Before("gorm:delete").Register("tracing:before_delete", gormBefore("gorm.delete")),
+		cb.Delete().After("gorm:delete").Register("tracing:after_delete", gormAfter),
+		cb.Row().Before("gorm:row").Register("tracing:before_row", gormBefore("gorm.row")),
+		cb.Row().After("gorm:row").Register("tracing:after_row", gormAfter),
+		cb.Raw().Before("gorm:raw").Register("tracing:before_raw", gormBefore("gorm.raw")),
+		cb.Raw().After("gorm:raw").Register("tracing:after_raw", gormAfter),
+	)
+}
+
+func gormBefore(spanName string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		parent := db.Statement.Context
+		if parent == nil {
+			parent = context.Background()
+		}
+		ctx, span := Start(parent, spanName, trace.WithSpanKind(trace.SpanKindClient))
+		db.Statement.Context = ctx
+		db.InstanceSet(gormSpanKey, gormSpan{span: span, parent: parent})
+	}
+}
+
+func gormAfter(db *gorm.DB) {
+	v, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	s := v.(gormSpan)
+	db.Statement.Context = s.parent
+	if s.span.IsRecording() {
+		s.span.SetAttributes(
+			attribute.String("db.system", db.Dialector.Name()),
+			attribute.String("db.sql.table", db.Statement.Table),
+			attribute.String("db.statement", db.Statement.SQL.String()),
+			attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+		)
+	}
+	// 查询不到记录属于正常业务结果，不标记为错误
+	err := db.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil
+	}
+	End(s.span, err)
+}
@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName 本服务创建的 span 使用的 tracer 名称
+const instrumentationName = "github.com/trancecho/mundo-points-system"
+
+// Init 按配置初始化全局 TracerProvider 和 W3C 传播器
+// tracing.exporter 支持 none（默认，只传播上下文不导出）和 stdout
+// 返回的函数在退出时调用，用于导出缓冲中的 span
+func Init() (func(context.Context) error, error) {
+	// 无论是否导出 span，都需要传播上游的 trace 上下文
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	switch exp := viper.GetString("tracing.exporter"); exp {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "stdout":
+		var err error
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, fmt.Errorf("创建 stdout 导出器失败: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("不支持的 tracing.exporter: %s", exp)
+	}
+
+	provider := NewProvider(exporter)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// NewProvider 使用指定导出器创建 TracerProvider
+// 测试时可传入 tracetest.NewInMemoryExporter()，读取 span 前先调用 ForceFlush
+// 采样率取 tracing.sample_ratio，未配置时全部采样；上游已采样的请求始终采样
+func NewProvider(exporter sdktrace.SpanExporter) *sdktrace.TracerProvider {
+	ratio := 1.0
+	if viper.IsSet("tracing.sample_ratio") {
+		ratio = viper.GetFloat64("tracing.sample_ratio")
+	}
+	res := resource.NewSchemaless(attribute.String("service.name", viper.GetString("service.name")))
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+}
+
+// Start 创建一个子 span，调用方负责 End
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+// End 记录错误（如有）并结束 span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
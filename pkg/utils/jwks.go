@@ -0,0 +1,212 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwksReloadInterval 遇到未知 kid 时重新读取 JWKS 文件的最小间隔
+const jwksReloadInterval = 10 * time.Second
+
+// jwksRefreshInterval 定期重新读取 JWKS 文件的间隔，从文件中移除的公钥最迟在这个间隔后失效
+const jwksRefreshInterval = time.Minute
+
+// jsonWebKey JWKS 文件中的单个公钥
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// verifyKey 验签公钥及其唯一允许的签名算法
+type verifyKey struct {
+	alg string
+	key crypto.PublicKey
+}
+
+// KeySet 从本地 JWKS 文件加载的验签公钥，按 kid 选择
+// 轮换密钥时新旧公钥同时放在文件中，旧 token 过期后再移除旧公钥
+type KeySet struct {
+	path string
+	keys atomic.Pointer[map[string]verifyKey]
+	mu   sync.Mutex
+	// 上次读取文件的时间（UnixNano），查找公钥时不加锁判断是否需要重新读取
+	loadedAt atomic.Int64
+}
+
+// LoadKeySet 读取 JWKS 文件
+func LoadKeySet(path string) (*KeySet, error) {
+	ks := &KeySet{path: path}
+	if err := ks.Reload(); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// Reload 重新读取 JWKS 文件，读取失败时保留原公钥
+func (ks *KeySet) Reload() error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	return ks.reloadLocked()
+}
+
+// SetPath 改为从 path 读取 JWKS 文件并立即重新读取，读取失败时保留原路径和原公钥
+func (ks *KeySet) SetPath(path string) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	oldPath := ks.path
+	ks.path = path
+	if err := ks.reloadLocked(); err != nil {
+		ks.path = oldPath
+		return err
+	}
+	return nil
+}
+
+// loadedSince 距离上次读取文件的时间
+func (ks *KeySet) loadedSince() time.Duration {
+	return time.Since(time.Unix(0, ks.loadedAt.Load()))
+}
+
+func (ks *KeySet) reloadLocked() error {
+	ks.loadedAt.Store(time.Now().UnixNano())
+	data, err := os.ReadFile(ks.path)
+	if err != nil {
+		return err
+	}
+
+	var file struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("解析JWKS失败: %w", err)
+	}
+
+	keys := make(map[string]verifyKey, len(file.Keys))
+	for _, jwk := range file.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if jwk.Kid == "" {
+			return errors.New("JWKS中的公钥缺少kid")
+		}
+		key, err := jwk.verifyKey()
+		if err != nil {
+			return fmt.Errorf("公钥 %s 无效: %w", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = key
+	}
+	ks.keys.Store(&keys)
+	return nil
+}
+
+// Lookup 按 kid 查找公钥
+// 距离上次读取超过 jwksRefreshInterval 时先重新读取文件，使移除的公钥失效；找不到时重新读取一次文件，以便尽快识别新加入的公钥
+func (ks *KeySet) Lookup(kid string) (verifyKey, bool) {
+	if ks.loadedSince() >= jwksRefreshInterval {
+		ks.mu.Lock()
+		// 并发请求只需要其中一个重新读取
+		if ks.loadedSince() >= jwksRefreshInterval {
+			if err := ks.reloadLocked(); err != nil {
+				slog.Warn("定期重新读取JWKS失败，继续使用原公钥", "path", ks.path, "error", err)
+			}
+		}
+		ks.mu.Unlock()
+	}
+	if key, ok := (*ks.keys.Load())[kid]; ok {
+		return key, true
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	if ks.loadedSince() >= jwksReloadInterval {
+		_ = ks.reloadLocked()
+	}
+	key, ok := (*ks.keys.Load())[kid]
+	return key, ok
+}
+
+// verifyKey 将 JWK 转换为公钥，只支持 RSA 对应 RS256、P-256 曲线对应 ES256
+func (jwk jsonWebKey) verifyKey() (verifyKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		if jwk.Alg != "" && jwk.Alg != jwt.SigningMethodRS256.Alg() {
+			return verifyKey{}, fmt.Errorf("不支持的算法 %s", jwk.Alg)
+		}
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return verifyKey{}, err
+		}
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return verifyKey{}, err
+		}
+		if n.BitLen() < 2048 {
+			return verifyKey{}, errors.New("RSA公钥长度不能小于2048位")
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return verifyKey{}, errors.New("RSA公钥指数无效")
+		}
+		return verifyKey{
+			alg: jwt.SigningMethodRS256.Alg(),
+			key: &rsa.PublicKey{N: n, E: int(e.Int64())},
+		}, nil
+	case "EC":
+		if jwk.Alg != "" && jwk.Alg != jwt.SigningMethodES256.Alg() {
+			return verifyKey{}, fmt.Errorf("不支持的算法 %s", jwk.Alg)
+		}
+		if jwk.Crv != "P-256" {
+			return verifyKey{}, fmt.Errorf("不支持的曲线 %s", jwk.Crv)
+		}
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return verifyKey{}, err
+		}
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return verifyKey{}, err
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+		if _, err := key.ECDH(); err != nil {
+			return verifyKey{}, errors.New("EC公钥不在曲线上")
+		}
+		return verifyKey{
+			alg: jwt.SigningMethodES256.Alg(),
+			key: key,
+		}, nil
+	default:
+		return verifyKey{}, fmt.Errorf("不支持的密钥类型 %s", jwk.Kty)
+	}
+}
+
+// decodeBigInt 解码 base64url 编码的大整数
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("公钥参数为空")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,319 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// testKey 测试用私钥及其 kid
+type testKey struct {
+	kid    string
+	method jwt.SigningMethod
+	key    interface{}
+}
+
+func newRSAKey(t *testing.T, kid string) testKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成RSA密钥失败: %v", err)
+	}
+	return testKey{kid: kid, method: jwt.SigningMethodRS256, key: key}
+}
+
+func newECKey(t *testing.T, kid string) testKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("生成EC密钥失败: %v", err)
+	}
+	return testKey{kid: kid, method: jwt.SigningMethodES256, key: key}
+}
+
+// jwk 将私钥对应的公钥转换为 JWK
+func (k testKey) jwk() jsonWebKey {
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	switch key := k.key.(type) {
+	case *rsa.PrivateKey:
+		return jsonWebKey{
+			Kty: "RSA",
+			Kid: k.kid,
+			Alg: "RS256",
+			Use: "sig",
+			N:   encode(key.N.Bytes()),
+			E:   encode(big.NewInt(int64(key.E)).Bytes()),
+		}
+	case *ecdsa.PrivateKey:
+		return jsonWebKey{
+			Kty: "EC",
+			Kid: k.kid,
+			Alg: "ES256",
+			Use: "sig",
+			Crv: "P-256",
+			X:   encode(key.X.FillBytes(make([]byte, 32))),
+			Y:   encode(key.Y.FillBytes(make([]byte, 32))),
+		}
+	}
+	panic("unsupported key type")
+}
+
+// sign 使用私钥签发 token，header 中带上 kid
+func (k testKey) sign(t *testing.T, userID int64) string {
+	t.Helper()
+	token := jwt.NewWithClaims(k.method, testClaims(userID))
+	token.Header["kid"] = k.kid
+	signed, err := token.SignedString(k.key)
+	if err != nil {
+		t.Fatalf("签发token失败: %v", err)
+	}
+	return signed
+}
+
+func testClaims(userID int64) Claims {
+	return Claims{
+		UserID:   userID,
+		Username: "tester",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+}
+
+// writeJWKS 将公钥写入 JWKS 文件
+func writeJWKS(t *testing.T, path string, keys ...testKey) {
+	t.Helper()
+	file := struct {
+		Keys []jsonWebKey `json:"keys"`
+	}{}
+	for _, key := range keys {
+		file.Keys = append(file.Keys, key.jwk())
+	}
+	data, err := json.Marshal(file)
+	if err != nil {
+		t.Fatalf("序列化JWKS失败: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("写入JWKS失败: %v", err)
+	}
+}
+
+func TestParseTokenSelectsKeyByKid(t *testing.T) {
+	rsaKey := newRSAKey(t, "rsa-1")
+	ecKey := newECKey(t, "ec-1")
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, rsaKey, ecKey)
+	keys, err := LoadKeySet(path)
+	if err != nil {
+		t.Fatalf("LoadKeySet: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		userID  int64
+		wantErr bool
+	}{
+		{name: "RS256", token: rsaKey.sign(t, 1), userID: 1},
+		{name: "ES256", token: ecKey.sign(t, 2), userID: 2},
+		// kid 指向另一把公钥时验签失败
+		{name: "kid指向错误的RSA公钥", token: testKey{kid: "rsa-1", method: jwt.SigningMethodRS256, key: newRSAKey(t, "").key}.sign(t, 3), wantErr: true},
+		// kid 指向 RSA 公钥但使用 ES256 签名
+		{name: "算法与公钥不匹配", token: testKey{kid: "rsa-1", method: jwt.SigningMethodES256, key: ecKey.key}.sign(t, 4), wantErr: true},
+		{name: "未知kid", token: newRSAKey(t, "rsa-unknown").sign(t, 5), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := parseToken(nil, keys, tt.token)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("parseToken 应返回错误")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseToken: %v", err)
+			}
+			if claims.UserID != tt.userID {
+				t.Fatalf("UserID = %d, want %d", claims.UserID, tt.userID)
+			}
+		})
+	}
+}
+
+func TestParseTokenRejectsSymmetricAndNoneWithAsymmetricKid(t *testing.T) {
+	rsaKey := newRSAKey(t, "rsa-1")
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, rsaKey)
+	keys, err := LoadKeySet(path)
+	if err != nil {
+		t.Fatalf("LoadKeySet: %v", err)
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(&rsaKey.key.(*rsa.PrivateKey).PublicKey)
+	if err != nil {
+		t.Fatalf("序列化公钥失败: %v", err)
+	}
+
+	// 用公钥作为 HS256 密钥签名，是算法混淆攻击的典型做法
+	hsToken := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims(1))
+	hsToken.Header["kid"] = "rsa-1"
+	hsSigned, err := hsToken.SignedString(publicKey)
+	if err != nil {
+		t.Fatalf("签发HS256 token失败: %v", err)
+	}
+	noneToken := jwt.NewWithClaims(jwt.SigningMethodNone, testClaims(1))
+	noneToken.Header["kid"] = "rsa-1"
+	noneSigned, err := noneToken.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("签发none token失败: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		secret []byte
+		token  string
+	}{
+		{name: "未配置HS256密钥", token: hsSigned},
+		{name: "已配置HS256密钥", secret: []byte("secretmundo"), token: hsSigned},
+		{name: "none算法", token: noneSigned},
+		{name: "none算法且配置了HS256密钥", secret: []byte("secretmundo"), token: noneSigned},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseToken(tt.secret, keys, tt.token); err == nil {
+				t.Fatal("parseToken 应拒绝该token")
+			}
+		})
+	}
+}
+
+func TestParseTokenAcceptsNewKidAfterReload(t *testing.T) {
+	oldKey := newRSAKey(t, "rsa-old")
+	newKey := newECKey(t, "ec-new")
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, oldKey)
+	keys, err := LoadKeySet(path)
+	if err != nil {
+		t.Fatalf("LoadKeySet: %v", err)
+	}
+	newToken := newKey.sign(t, 2)
+	if _, err := parseToken(nil, keys, newToken); err == nil {
+		t.Fatal("轮换前新kid不应通过验签")
+	}
+
+	// 轮换期间新旧公钥同时存在
+	writeJWKS(t, path, oldKey, newKey)
+	if err := keys.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	for name, token := range map[string]string{"旧kid": oldKey.sign(t, 1), "新kid": newToken} {
+		if _, err := parseToken(nil, keys, token); err != nil {
+			t.Fatalf("%s 验签失败: %v", name, err)
+		}
+	}
+
+	// 文件损坏时保留已加载的公钥
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatalf("写入JWKS失败: %v", err)
+	}
+	if err := keys.Reload(); err == nil {
+		t.Fatal("Reload 应返回错误")
+	}
+	if _, err := parseToken(nil, keys, newToken); err != nil {
+		t.Fatalf("读取失败后原公钥丢失: %v", err)
+	}
+}
+
+func TestLookupReloadsUnknownKid(t *testing.T) {
+	oldKey := newRSAKey(t, "rsa-old")
+	newKey := newECKey(t, "ec-new")
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, oldKey)
+	keys, err := LoadKeySet(path)
+	if err != nil {
+		t.Fatalf("LoadKeySet: %v", err)
+	}
+	writeJWKS(t, path, oldKey, newKey)
+
+	// 距离上次读取不足间隔时不重新读取文件
+	if _, ok := keys.Lookup("ec-new"); ok {
+		t.Fatal("未到重新读取间隔时不应识别新kid")
+	}
+
+	keys.loadedAt.Store(time.Now().Add(-jwksReloadInterval).UnixNano())
+	if _, err := parseToken(nil, keys, newKey.sign(t, 2)); err != nil {
+		t.Fatalf("遇到未知kid时应重新读取文件: %v", err)
+	}
+}
+
+func TestLookupDropsRemovedKidAfterRefresh(t *testing.T) {
+	oldKey := newRSAKey(t, "rsa-old")
+	newKey := newECKey(t, "ec-new")
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, oldKey, newKey)
+	keys, err := LoadKeySet(path)
+	if err != nil {
+		t.Fatalf("LoadKeySet: %v", err)
+	}
+	oldToken := oldKey.sign(t, 1)
+
+	// 旧公钥从文件中移除，未到定期读取间隔时仍可验签
+	writeJWKS(t, path, newKey)
+	if _, err := parseToken(nil, keys, oldToken); err != nil {
+		t.Fatalf("未到定期读取间隔时旧kid验签失败: %v", err)
+	}
+
+	keys.loadedAt.Store(time.Now().Add(-jwksRefreshInterval).UnixNano())
+	if _, err := parseToken(nil, keys, oldToken); err == nil {
+		t.Fatal("定期重新读取后已移除的kid不应通过验签")
+	}
+	if _, err := parseToken(nil, keys, newKey.sign(t, 2)); err != nil {
+		t.Fatalf("新kid验签失败: %v", err)
+	}
+}
+
+func TestSetPathSwitchesFile(t *testing.T) {
+	oldKey := newRSAKey(t, "rsa-old")
+	newKey := newECKey(t, "ec-new")
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "old.json")
+	newPath := filepath.Join(dir, "new.json")
+	writeJWKS(t, oldPath, oldKey)
+	writeJWKS(t, newPath, newKey)
+	keys, err := LoadKeySet(oldPath)
+	if err != nil {
+		t.Fatalf("LoadKeySet: %v", err)
+	}
+
+	// 新路径读取失败时保留原路径和原公钥
+	if err := keys.SetPath(filepath.Join(dir, "missing.json")); err == nil {
+		t.Fatal("SetPath 应返回错误")
+	}
+	if keys.path != oldPath {
+		t.Fatalf("读取失败后路径 = %s, want %s", keys.path, oldPath)
+	}
+	if _, err := parseToken(nil, keys, oldKey.sign(t, 1)); err != nil {
+		t.Fatalf("读取失败后原公钥丢失: %v", err)
+	}
+
+	if err := keys.SetPath(newPath); err != nil {
+		t.Fatalf("SetPath: %v", err)
+	}
+	if _, err := parseToken(nil, keys, newKey.sign(t, 2)); err != nil {
+		t.Fatalf("切换文件后新kid验签失败: %v", err)
+	}
+	if _, err := parseToken(nil, keys, oldKey.sign(t, 1)); err == nil {
+		t.Fatal("切换文件后原文件中的kid不应通过验签")
+	}
+}
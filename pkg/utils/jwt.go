@@ -2,22 +2,61 @@ package utils
 
 import (
 	"errors"
+	"time"
+
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/spf13/viper"
-	"log"
-	"time"
+	"github.com/trancecho/mundo-points-system/pkg/logger"
 )
 
 var MundoSecret []byte
 var OffercatSecret []byte
 
+// MundoKeys 验证 RS256/ES256 token 的公钥，未配置 jwt.jwks_file 时为 nil
+var MundoKeys *KeySet
+
 func InitSecret() {
-	MundoSecret = []byte(viper.GetString("jwt.jwt_sec") + "mundo")
-	//log.Println("MundoSecret: ", MundoSecret)
-	//OffercatSecret = []byte(config.GetConfig().Jwt.Offercat + "offercat")
-	//log.Println("OffercatSecret: ", OffercatSecret)
+	// 未配置密钥时不接受 HS256 token，避免退化成固定的弱密钥
+	if secret := viper.GetString("jwt.jwt_sec"); secret != "" {
+		MundoSecret = []byte(secret + "mundo")
+	}
+	if secret := viper.GetString("jwt.offercat_sec"); secret != "" {
+		OffercatSecret = []byte(secret + "offercat")
+	}
+	if path := viper.GetString("jwt.jwks_file"); path != "" {
+		keys, err := LoadKeySet(path)
+		if err != nil {
+			logger.Fatal("加载JWKS失败", "error", err)
+		}
+		MundoKeys = keys
+	}
+	if err := InitServiceSecrets(); err != nil {
+		logger.Fatal("加载服务凭证失败", "error", err)
+	}
 }
 
+// ReloadMundoKeys 配置文件变更后按 jwt.jwks_file 重新读取 JWKS，路径变化时改为读取新文件，失败时保留原公钥
+// 启用或停用 JWKS 需要重启服务
+func ReloadMundoKeys() error {
+	path := viper.GetString("jwt.jwks_file")
+	if MundoKeys == nil {
+		if path != "" {
+			return errors.New("启用 jwt.jwks_file 需要重启服务")
+		}
+		return nil
+	}
+	if path == "" {
+		return errors.New("停用 jwt.jwks_file 需要重启服务")
+	}
+	return MundoKeys.SetPath(path)
+}
+
+// 内置角色
+const (
+	RoleAdmin   = "admin"
+	RoleService = "service"
+)
+
 type Claims struct {
 	UserID   int64  `json:"user_id"`
 	Username string `json:"username"`
@@ -53,18 +92,44 @@ func GenerateToken(userID int64, username, role string, service string) (string,
 // 验证 JWT Token
 func ParseToken(service string, tokenString string) (*Claims, error) {
 	if service == "mundo" {
-		return parseToken(MundoSecret, tokenString)
+		return parseToken(MundoSecret, MundoKeys, tokenString)
 	} else {
-		return parseToken(OffercatSecret, tokenString)
+		return parseToken(OffercatSecret, nil, tokenString)
 	}
 }
 
-func parseToken(secret []byte, tokenString string) (*Claims, error) {
-	log.Println("tokenString: ", tokenString)
-	//log.Println("OffercatSecret: ", OffercatSecret)
+// parseToken 验证 token，签名算法只接受已配置密钥对应的算法
+// HS256 使用 secret，RS256/ES256 按 header 中的 kid 从 keys 选择公钥，且公钥只能验证它自己的算法
+func parseToken(secret []byte, keys *KeySet, tokenString string) (*Claims, error) {
+	var methods []string
+	if len(secret) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if keys != nil {
+		methods = append(methods, jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("未配置验签密钥")
+	}
+
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return secret, nil
-	})
+		if token.Method.Alg() == jwt.SigningMethodHS256.Alg() {
+			return secret, nil
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			return nil, errors.New("token缺少kid")
+		}
+		key, ok := keys.Lookup(kid)
+		if !ok {
+			return nil, errors.New("未知的kid")
+		}
+		if key.alg != token.Method.Alg() {
+			return nil, errors.New("签名算法与公钥不匹配")
+		}
+		return key.key, nil
+	}, jwt.WithValidMethods(methods))
 
 	if err != nil {
 		return nil, err
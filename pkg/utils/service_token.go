@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/viper"
+)
+
+// ServiceSecrets 各调用方服务的签名密钥，键为服务名
+var ServiceSecrets map[string][]byte
+
+// ServiceCredential 调用方服务的密钥配置
+type ServiceCredential struct {
+	Name   string `mapstructure:"name"`
+	Secret string `mapstructure:"secret"`
+}
+
+// InitServiceSecrets 从配置项 service_auth.services 读取调用方服务的密钥
+// 服务凭证的 aud 必须是本服务的 service.name，配置了调用方但没有配置 service.name 时返回错误
+func InitServiceSecrets() error {
+	var credentials []ServiceCredential
+	if err := viper.UnmarshalKey("service_auth.services", &credentials); err != nil {
+		return fmt.Errorf("解析 service_auth.services 配置失败: %w", err)
+	}
+	if len(credentials) > 0 && viper.GetString("service.name") == "" {
+		return errors.New("配置了 service_auth.services 时必须配置 service.name")
+	}
+	ServiceSecrets = make(map[string][]byte, len(credentials))
+	for _, credential := range credentials {
+		if credential.Name != "" && credential.Secret != "" {
+			ServiceSecrets[credential.Name] = []byte(credential.Secret)
+		}
+	}
+	return nil
+}
+
+// GenerateServiceToken 生成服务间调用凭证，sub 为调用方服务名，aud 为被调用的服务名
+func GenerateServiceToken(service string, audience string, ttl time.Duration) (string, error) {
+	secret, ok := ServiceSecrets[service]
+	if !ok {
+		return "", errors.New("未配置该服务的密钥")
+	}
+
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		Subject:   service,
+		Audience:  jwt.ClaimStrings{audience},
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		IssuedAt:  jwt.NewNumericDate(now),
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+}
+
+// ParseServiceToken 验证服务间调用凭证，返回调用方服务名
+// 按 sub 选择对应服务的密钥，aud 必须是本服务的名字
+func ParseServiceToken(tokenString string) (string, error) {
+	audience := viper.GetString("service.name")
+	if audience == "" {
+		return "", errors.New("未配置 service.name，不接受服务凭证")
+	}
+
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		secret, ok := ServiceSecrets[claims.Subject]
+		if !ok {
+			return nil, errors.New("未知的调用方服务")
+		}
+		return secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithAudience(audience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return claims.Subject, nil
+}
@@ -0,0 +1,26 @@
+package po
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// PointChange 一次积分和经验变更
+type PointChange struct {
+	UserID     string
+	Points     int64
+	Experience int64
+	Reason     string
+	// 幂等键，非空时同一个键只会生效一次
+	IdempotencyKey string
+	// 该来源每天最多获得的积分，只限制收入，小于等于 0 表示不限
+	DailyLimit int64
+}
+
+// RequestHash 返回变更请求内容的摘要，幂等键相同但内容不同的请求摘要不同
+// 摘要按请求的积分计算，不受每日额度影响
+func (c PointChange) RequestHash() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d|%d|%s", c.UserID, c.Points, c.Experience, c.Reason)))
+	return hex.EncodeToString(sum[:])
+}
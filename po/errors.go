@@ -0,0 +1,36 @@
+package po
+
+import "errors"
+
+var (
+	// ErrUserNotFound 用户不存在
+	ErrUserNotFound = errors.New("用户不存在")
+	// ErrPointsInsufficient 积分不足
+	ErrPointsInsufficient = errors.New("积分不足")
+	// ErrTransferLimitExceeded 超出每日转账限额
+	ErrTransferLimitExceeded = errors.New("超出每日转账限额")
+	// ErrEarnLimitExceeded 已达到今日积分获取上限
+	ErrEarnLimitExceeded = errors.New("已达到今日积分获取上限")
+	// ErrAlreadyLiked 已经点过赞
+	ErrAlreadyLiked = errors.New("已经点过赞")
+	// ErrLikeNotFound 没有点过赞
+	ErrLikeNotFound = errors.New("没有点过赞")
+	// ErrIdempotencyKeyConflict 幂等键已被其他用户或内容不同的请求占用
+	ErrIdempotencyKeyConflict = errors.New("幂等键冲突")
+	// ErrUnsupportedLeaderboard 排行榜不支持该指标，周期榜只支持积分和经验
+	ErrUnsupportedLeaderboard = errors.New("不支持的排行榜指标")
+	// ErrRewardItemNotFound 商品不存在
+	ErrRewardItemNotFound = errors.New("商品不存在")
+	// ErrRewardItemUnavailable 商品未上架或不在兑换时间内
+	ErrRewardItemUnavailable = errors.New("商品当前不可兑换")
+	// ErrOutOfStock 库存不足
+	ErrOutOfStock = errors.New("库存不足")
+	// ErrRedeemLimitExceeded 超出每人兑换数量上限
+	ErrRedeemLimitExceeded = errors.New("超出每人兑换数量上限")
+	// ErrPointRecordNotFound 积分记录不存在
+	ErrPointRecordNotFound = errors.New("积分记录不存在")
+	// ErrAlreadyReversed 积分记录已经被冲正
+	ErrAlreadyReversed = errors.New("积分记录已经被冲正")
+	// ErrRecordNotReversible 积分记录不能单独冲正，如冲正记录、转账、点赞、过期和管理员调整记录
+	ErrRecordNotReversible = errors.New("积分记录不能冲正")
+)
@@ -0,0 +1,31 @@
+package po
+
+import "time"
+
+// PointDirection 积分变动方向
+type PointDirection int
+
+const (
+	PointDirectionAll   PointDirection = iota // 全部
+	PointDirectionEarn                        // 收入
+	PointDirectionSpend                       // 支出
+)
+
+// PointRecordFilter 积分记录查询条件
+type PointRecordFilter struct {
+	UserID    string
+	BeforeID  int64     // 游标，只返回 ID 小于该值的记录，0 表示从最新一条开始
+	Limit     int       // 返回条数
+	StartTime time.Time // 起始时间（包含），零值表示不限
+	EndTime   time.Time // 结束时间（不包含），零值表示不限
+	Reason    string
+	Direction PointDirection
+}
+
+// AdminAuditFilter 审计记录查询条件
+type AdminAuditFilter struct {
+	OperatorID string // 为空表示所有操作人
+	UserID     string // 为空表示所有用户
+	BeforeID   int64  // 游标，只返回 ID 小于该值的记录，0 表示从最新一条开始
+	Limit      int
+}
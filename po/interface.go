@@ -1,19 +1,38 @@
 package po
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // UserRepository 用户仓库接口
 type UserRepository interface {
 	GetUserByID(ctx context.Context, userID string) (*UserInfo, error)
+	GetUsersByIDs(ctx context.Context, userIDs []string) ([]*UserInfo, error)
+	EnsureUser(ctx context.Context, userID int64, username string, initialPoints int64) (*UserInfo, bool, error)
 	UpdateSignStatus(ctx context.Context, userID string, isSigned bool, continuousDay int32, totalDay int32) error
-	UpdateLevelByExperience(ctx context.Context, userID string) error
+	UpdateLevelByExperience(ctx context.Context, userID string) (oldLevel int, newLevel int, err error)
 	UpdateActivityScore(ctx context.Context, userID string, deltaScore int64) error
+	RecalculateLevels(ctx context.Context) (int64, error)
+}
+
+// LevelProvider 提供当前生效的等级曲线
+type LevelProvider interface {
+	Curve() LevelCurve
 }
 
 // PointRepository 积分仓库接口
 type PointRepository interface {
-	AddPointsAndExperience(ctx context.Context, userID string, points int64, experience int64, reason string) error
-	RecordLike(ctx context.Context, userID string, postID string, targetUserID string) error
+	AddPointsAndExperience(ctx context.Context, change PointChange) (*PointRecord, error)
+	RecordLike(ctx context.Context, userID string, postID string, targetUserID string, points int64, experience int64, dailyLimit int64) error
+	UnrecordLike(ctx context.Context, userID string, postID string) (string, error)
+	ListPointRecords(ctx context.Context, filter PointRecordFilter) ([]*PointRecord, error)
+	TransferPoints(ctx context.Context, fromUserID string, toUserID string, points int64, dailyLimit int64) (string, error)
+	ExpireLots(ctx context.Context, now time.Time, limit int) (int, error)
+	SumExpiringPoints(ctx context.Context, userID string, before time.Time) (int64, *time.Time, error)
+	AdminAdjustPoints(ctx context.Context, audit *AdminAuditRecord) error
+	ListAdminAudits(ctx context.Context, filter AdminAuditFilter) ([]*AdminAuditRecord, error)
+	ReversePointRecord(ctx context.Context, recordID int64, operatorID string) (*PointRecord, error)
 }
 
 // StatisticsRepository 统计仓库接口
@@ -21,4 +40,15 @@ type StatisticsRepository interface {
 	GetLevelDistribution(ctx context.Context) (map[int]int64, error)
 	GetAveragePoints(ctx context.Context) (float32, error)
 	GetMonthlyPointsUsed(ctx context.Context) (int64, error)
+	GetLeaderboard(ctx context.Context, query LeaderboardQuery) ([]*LeaderboardEntry, error)
+	GetLeaderboardRank(ctx context.Context, query LeaderboardQuery, userID string) (*LeaderboardEntry, error)
+}
+
+// ShopRepository 积分商城仓库接口
+type ShopRepository interface {
+	CreateRewardItem(ctx context.Context, item *RewardItem) error
+	UpdateRewardItem(ctx context.Context, item *RewardItem) error
+	ListRewardItems(ctx context.Context, availableAt time.Time) ([]*RewardItem, error)
+	RedeemItem(ctx context.Context, userID string, itemID int64, quantity int64) (*RedeemOrder, error)
+	ListRedeemOrders(ctx context.Context, filter RedeemOrderFilter) ([]*RedeemOrder, error)
 }
@@ -0,0 +1,29 @@
+package po
+
+import "time"
+
+// LeaderboardMetric 排行榜指标
+type LeaderboardMetric int
+
+const (
+	LeaderboardPoints            LeaderboardMetric = iota // 积分
+	LeaderboardExperience                                 // 经验
+	LeaderboardActivityScore                              // 活跃度
+	LeaderboardContinuousSignDay                          // 连续签到天数
+)
+
+// LeaderboardQuery 排行榜查询条件
+type LeaderboardQuery struct {
+	Metric LeaderboardMetric
+	// 周期起始时间，零值表示总榜；周期榜按积分记录的变化量统计，只支持积分和经验
+	Since  time.Time
+	Limit  int
+	Offset int
+}
+
+// LeaderboardEntry 排行榜条目
+type LeaderboardEntry struct {
+	Rank   int64 // 名次，从 1 开始，数值相同时按用户ID排序
+	UserID string
+	Value  int64
+}
@@ -0,0 +1,25 @@
+package po
+
+// LevelDefinition 等级定义，经验值达到 MinExperience 即可升到该等级
+type LevelDefinition struct {
+	Level         int      `mapstructure:"level"`
+	Name          string   `mapstructure:"name"`
+	MinExperience int64    `mapstructure:"min_experience"`
+	Perks         []string `mapstructure:"perks"`
+}
+
+// LevelCurve 等级曲线，按等级升序排列
+type LevelCurve []LevelDefinition
+
+// LevelOf 根据经验值计算等级
+func (c LevelCurve) LevelOf(experience int64) int {
+	for i := len(c) - 1; i >= 0; i-- {
+		if experience >= c[i].MinExperience {
+			return c[i].Level
+		}
+	}
+	if len(c) > 0 {
+		return c[0].Level
+	}
+	return 1
+}
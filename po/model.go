@@ -7,14 +7,34 @@ type UserInfo struct {
 	BaseModel
 	UserID            int64     `gorm:"column:user_id;not null;unique"`
 	Username          string    `gorm:"column:username;not null"`
-	Points            int64     `gorm:"column:points;not null;default:0"`
-	Experience        int64     `gorm:"column:experience;not null;default:0"`
+	Points            int64     `gorm:"column:points;not null;default:0;index"`
+	Experience        int64     `gorm:"column:experience;not null;default:0;index"`
 	Level             int       `gorm:"column:level;not null;default:1"`
 	IsSigned          bool      `gorm:"column:is_signed;not null;default:false"`
-	ContinuousSignDay int32     `gorm:"column:continuous_sign_day;not null;default:0"`
+	ContinuousSignDay int32     `gorm:"column:continuous_sign_day;not null;default:0;index"`
 	TotalSignDay      int32     `gorm:"column:total_sign_day;not null;default:0"`
 	LastSignDate      time.Time `gorm:"column:last_sign_date"`
-	ActivityScore     int64     `gorm:"column:activity_score;default:0"`
+	ActivityScore     int64     `gorm:"column:activity_score;default:0;index"`
+}
+
+// 系统产生的积分记录原因
+const (
+	ReasonTransferOut = "积分转出"
+	ReasonTransferIn  = "积分转入"
+	ReasonLiked       = "被点赞"
+	ReasonUnliked     = "取消点赞"
+	ReasonWelcome     = "新用户奖励"
+	ReasonRedeem      = "兑换商品"
+	ReasonExpired     = "积分过期"
+	ReasonAdminAdjust = "管理员调整"
+	ReasonReversal    = "积分冲正"
+	ReasonSign        = "每日签到"
+)
+
+// SystemReasons 系统产生的积分记录原因，UpdatePointsAndExperience 的调用方可以传入任意原因
+var SystemReasons = []string{
+	ReasonTransferOut, ReasonTransferIn, ReasonLiked, ReasonUnliked, ReasonWelcome,
+	ReasonRedeem, ReasonExpired, ReasonAdminAdjust, ReasonReversal, ReasonSign,
 }
 
 // PointRecord 积分记录模型
@@ -24,12 +44,52 @@ type PointRecord struct {
 	Points     int64  `gorm:"column:points;not null"`
 	Experience int64  `gorm:"column:experience;not null"`
 	Reason     string `gorm:"column:reason;not null"`
+	// 幂等键，为空时存 NULL，不参与唯一约束
+	IdempotencyKey *string `gorm:"column:idempotency_key;size:128;uniqueIndex"`
+	// 使用幂等键的请求内容摘要，重放时用来确认是同一个请求
+	RequestHash string `gorm:"column:request_hash;size:64"`
+	// 转账ID，同一笔转账的转出和转入记录共用
+	TransferID string `gorm:"column:transfer_id;size:64;index"`
+	// 关联用户，如点赞者
+	RelatedUserID string `gorm:"column:related_user_id;size:64"`
+	// 关联业务ID，如被点赞的帖子ID
+	RefID string `gorm:"column:ref_id;size:128;index"`
+	// 收入记录作为一个积分批次，Remaining 为批次中尚未被扣减或过期的积分
+	Remaining int64 `gorm:"column:remaining;not null;default:0"`
+	// 收入记录为批次过期时间，为空表示不过期；支出记录为所扣批次中最早的过期时间，转入和冲正时沿用
+	ExpiresAt *time.Time `gorm:"column:expires_at;index"`
+	// 冲正记录对应的原记录ID，唯一约束保证一条记录只能被冲正一次
+	ReversesID *int64 `gorm:"column:reverses_id;uniqueIndex"`
 }
 
 // LikeRecord 点赞记录模型
 type LikeRecord struct {
 	BaseModel
-	UserID       string `gorm:"column:user_id;not null;index"`
-	PostID       string `gorm:"column:post_id;not null;index"`
+	// 同一用户对同一帖子只有一条点赞记录，取消后再点赞恢复原记录
+	UserID       string `gorm:"column:user_id;not null;index;uniqueIndex:idx_like_user_post"`
+	PostID       string `gorm:"column:post_id;not null;index;uniqueIndex:idx_like_user_post"`
 	TargetUserID string `gorm:"column:target_user_id;not null;index"`
+	// 被点赞者当前是否持有这次点赞的积分，取消点赞后重新点赞不再发放积分
+	Rewarded bool `gorm:"column:rewarded;not null;default:true"`
+}
+
+// LevelChangeRecord 等级变更记录模型
+type LevelChangeRecord struct {
+	BaseModel
+	UserID     string `gorm:"column:user_id;not null;index"`
+	OldLevel   int    `gorm:"column:old_level;not null"`
+	NewLevel   int    `gorm:"column:new_level;not null"`
+	Experience int64  `gorm:"column:experience;not null"` // 变更时的经验值
+}
+
+// AdminAuditRecord 管理员调整积分和冲正积分记录的审计记录，与对应的积分记录在同一事务内写入
+type AdminAuditRecord struct {
+	BaseModel
+	OperatorID    string `gorm:"column:operator_id;not null;index"` // 操作人用户ID，服务账号为 service:服务名
+	UserID        string `gorm:"column:user_id;not null;index"`     // 被调整的用户ID
+	Points        int64  `gorm:"column:points;not null"`
+	Experience    int64  `gorm:"column:experience;not null"`
+	Reason        string `gorm:"column:reason;size:512;not null"` // 调整原因
+	Ticket        string `gorm:"column:ticket;size:128;not null"` // 工单号
+	PointRecordID int64  `gorm:"column:point_record_id;not null"` // 对应的积分记录
 }
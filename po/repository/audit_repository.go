@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/trancecho/mundo-points-system/po"
+)
+
+// AdminAdjustPoints 管理员调整用户积分和经验，积分记录和审计记录在同一事务内写入
+// 写入成功后 audit.ID 和 audit.PointRecordID 为新记录的ID
+func (r *PointRepositoryImpl) AdminAdjustPoints(ctx context.Context, audit *po.AdminAuditRecord) error {
+	// 开启事务
+	tx := r.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	// 扣减积分时同样检查余额，积分不足返回 po.ErrPointsInsufficient
+	record := &po.PointRecord{
+		UserID:        audit.UserID,
+		Points:        audit.Points,
+		Experience:    audit.Experience,
+		Reason:        po.ReasonAdminAdjust,
+		RelatedUserID: audit.OperatorID,
+		RefID:         audit.Ticket,
+	}
+	if err := applyPointRecord(tx, record); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	audit.PointRecordID = record.ID
+	if err := tx.Create(audit).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return commitPointRecords(tx, record)
+}
+
+// ListAdminAudits 按 ID 倒序查询审计记录
+func (r *PointRepositoryImpl) ListAdminAudits(ctx context.Context, filter po.AdminAuditFilter) ([]*po.AdminAuditRecord, error) {
+	query := r.db.WithContext(ctx).Model(&po.AdminAuditRecord{})
+	if filter.OperatorID != "" {
+		query = query.Where("operator_id = ?", filter.OperatorID)
+	}
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.BeforeID > 0 {
+		query = query.Where("id < ?", filter.BeforeID)
+	}
+
+	var audits []*po.AdminAuditRecord
+	if err := query.Order("id DESC").Limit(filter.Limit).Find(&audits).Error; err != nil {
+		return nil, err
+	}
+	return audits, nil
+}
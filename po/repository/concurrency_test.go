@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/trancecho/mundo-points-system/pkg/testdb"
+	"github.com/trancecho/mundo-points-system/po"
+)
+
+// TestConcurrentDebitsKeepLedgerConsistent 并发扣减同一用户的积分
+// 余额不能为负，且等于该用户积分记录之和，批次剩余之和等于余额
+func TestConcurrentDebitsKeepLedgerConsistent(t *testing.T) {
+	testdb.RequireMySQL(t)
+	db := testdb.Open(t)
+	repo := NewPointRepository(db)
+	withLifetime(t, 12)
+
+	userID := createUser(t, db, 1001, 0)
+	addPoints(t, repo, userID, 1000)
+
+	// 总扣减量远大于余额，保证有请求因积分不足失败
+	const workers = 20
+	const rounds = 10
+	const debit = 30
+	var succeeded atomic.Int64
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*rounds)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < rounds; j++ {
+				_, err := repo.AddPointsAndExperience(context.Background(), po.PointChange{
+					UserID: userID,
+					Points: -debit,
+					Reason: "测试扣减",
+				})
+				switch {
+				case err == nil:
+					succeeded.Add(1)
+				case !errors.Is(err, po.ErrPointsInsufficient):
+					errs <- err
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("并发扣减失败: %v", err)
+	}
+
+	points := userPoints(t, db, userID)
+	if points < 0 {
+		t.Fatalf("余额为负: %d", points)
+	}
+	if want := 1000 - succeeded.Load()*debit; points != want {
+		t.Fatalf("余额 = %d, 成功扣减 %d 次, want %d", points, succeeded.Load(), want)
+	}
+	// 余额足够时扣减不应失败
+	if points >= debit {
+		t.Fatalf("余额还剩 %d，仍有扣减因积分不足失败", points)
+	}
+	var ledger int64
+	if err := db.Model(&po.PointRecord{}).
+		Select("COALESCE(SUM(points), 0)").
+		Where("user_id = ?", userID).
+		Scan(&ledger).Error; err != nil {
+		t.Fatalf("汇总积分记录失败: %v", err)
+	}
+	if points != ledger {
+		t.Fatalf("余额 %d 与积分记录之和 %d 不一致", points, ledger)
+	}
+	if remaining := lotRemaining(t, db, userID); remaining != points {
+		t.Fatalf("批次剩余 %d 与余额 %d 不一致", remaining, points)
+	}
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/trancecho/mundo-points-system/po"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// lotLifetimeMonths 新积分批次的有效月数，0 表示不过期
+var lotLifetimeMonths atomic.Int64
+
+// SetPointLifetime 设置之后获得的积分多少个月后过期，0 表示不过期，已有批次的过期时间不变
+func SetPointLifetime(months int) {
+	lotLifetimeMonths.Store(int64(months))
+}
+
+// lotExpiresAt 在 now 获得的积分批次的过期时间
+func lotExpiresAt(now time.Time) *time.Time {
+	months := lotLifetimeMonths.Load()
+	if months <= 0 {
+		return nil
+	}
+	expiresAt := now.AddDate(0, int(months), 0)
+	return &expiresAt
+}
+
+// consumeLots 扣减用户的积分批次余额，调用前需已锁定用户行并完成积分扣减，返回被扣批次中最早的过期时间
+// 启用批次之前的历史积分没有批次记录，也不会过期，优先扣这部分积分，再按获得顺序扣减批次
+// 只扣了历史积分或不过期的批次时返回 nil
+func consumeLots(tx *gorm.DB, userID string, amount int64) (*time.Time, error) {
+	// 锁定批次，过期清理等直接修改批次的流程不会与扣减交错
+	var lots []po.PointRecord
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "remaining", "expires_at").
+		Where("user_id = ? AND remaining > 0", userID).
+		Order("id ASC").
+		Find(&lots).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// 扣减前的积分减去批次余额即为历史积分
+	var user po.UserInfo
+	if err := tx.Select("points").Where("user_id = ?", userID).Take(&user).Error; err != nil {
+		return nil, err
+	}
+	legacy := user.Points + amount
+	for _, lot := range lots {
+		legacy -= lot.Remaining
+	}
+	amount -= min(max(legacy, 0), amount)
+
+	var earliest *time.Time
+	for _, lot := range lots {
+		if amount == 0 {
+			break
+		}
+		take := min(lot.Remaining, amount)
+		if err := tx.Model(&po.PointRecord{}).
+			Where("id = ?", lot.ID).
+			Update("remaining", gorm.Expr("remaining - ?", take)).Error; err != nil {
+			return nil, err
+		}
+		amount -= take
+		if lot.ExpiresAt != nil && (earliest == nil || lot.ExpiresAt.Before(*earliest)) {
+			earliest = lot.ExpiresAt
+		}
+	}
+	return earliest, nil
+}
+
+// legacyPoints 用户启用批次之前的历史积分，即当前积分减去批次余额，调用前需已锁定用户行
+func legacyPoints(tx *gorm.DB, userID string) (int64, error) {
+	var user po.UserInfo
+	if err := tx.Select("points").Where("user_id = ?", userID).Take(&user).Error; err != nil {
+		return 0, err
+	}
+	var remaining int64
+	if err := tx.Model(&po.PointRecord{}).
+		Select("COALESCE(SUM(remaining), 0)").
+		Where("user_id = ? AND remaining > 0", userID).
+		Scan(&remaining).Error; err != nil {
+		return 0, err
+	}
+	return user.Points - remaining, nil
+}
+
+// ExpireLots 作废 now 之前过期的积分批次，每个用户写入一条积分过期记录
+// 每次最多处理 limit 个用户，返回处理的用户数，返回值等于 limit 时可能还有未处理的用户
+func (r *PointRepositoryImpl) ExpireLots(ctx context.Context, now time.Time, limit int) (int, error) {
+	var userIDs []string
+	err := r.db.WithContext(ctx).
+		Model(&po.PointRecord{}).
+		Distinct("user_id").
+		Where("expires_at <= ? AND remaining > 0", now).
+		Limit(limit).
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return 0, err
+	}
+
+	for i, userID := range userIDs {
+		if err := r.expireUserLots(ctx, userID, now); err != nil {
+			return i, err
+		}
+	}
+	return len(userIDs), nil
+}
+
+// expireUserLots 在一个事务内作废单个用户的过期批次并扣除对应积分
+func (r *PointRepositoryImpl) expireUserLots(ctx context.Context, userID string, now time.Time) error {
+	// 开启事务
+	tx := r.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	// 先锁定用户行，与 applyPointRecord 的加锁顺序一致
+	var user po.UserInfo
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ?", userID).
+		First(&user).Error
+	userExists := err == nil
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return err
+	}
+
+	var lots []po.PointRecord
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "remaining").
+		Where("user_id = ? AND expires_at <= ? AND remaining > 0", userID, now).
+		Find(&lots).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	var total int64
+	ids := make([]int64, 0, len(lots))
+	for _, lot := range lots {
+		total += lot.Remaining
+		ids = append(ids, lot.ID)
+	}
+	if len(ids) > 0 {
+		if err := tx.Model(&po.PointRecord{}).Where("id IN ?", ids).Update("remaining", 0).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	// 用户已删除时只作废批次
+	if !userExists || total == 0 {
+		return tx.Commit().Error
+	}
+
+	// 批次余额之和不会超过用户积分，这里只是防止历史数据不一致时把积分扣成负数
+	expired := min(total, user.Points)
+	if err := tx.Model(&po.UserInfo{}).
+		Where("user_id = ?", userID).
+		Update("points", gorm.Expr("points - ?", expired)).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	record := &po.PointRecord{
+		UserID: userID,
+		Points: -expired,
+		Reason: po.ReasonExpired,
+	}
+	if err := tx.Create(record).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return commitPointRecords(tx, record)
+}
+
+// SumExpiringPoints 统计用户在 before 之前将要过期的积分，以及其中最早的过期时间
+func (r *PointRepositoryImpl) SumExpiringPoints(ctx context.Context, userID string, before time.Time) (int64, *time.Time, error) {
+	query := r.db.WithContext(ctx).
+		Model(&po.PointRecord{}).
+		Where("user_id = ? AND remaining > 0 AND expires_at IS NOT NULL AND expires_at <= ?", userID, before)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Select("COALESCE(SUM(remaining), 0)").Scan(&total).Error; err != nil {
+		return 0, nil, err
+	}
+	if total == 0 {
+		return 0, nil, nil
+	}
+
+	// 单独查询最早的批次，聚合函数返回的时间在部分驱动中无法直接扫描为 time.Time
+	var earliest po.PointRecord
+	if err := query.Session(&gorm.Session{}).Select("expires_at").Order("expires_at ASC").Take(&earliest).Error; err != nil {
+		return 0, nil, err
+	}
+
+	return total, earliest.ExpiresAt, nil
+}
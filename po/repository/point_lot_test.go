@@ -0,0 +1,313 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/trancecho/mundo-points-system/pkg/testdb"
+	"github.com/trancecho/mundo-points-system/po"
+	"gorm.io/gorm"
+)
+
+// createUser 直接写入用户，points 为启用批次之前的历史积分，没有批次记录
+func createUser(t *testing.T, db *gorm.DB, userID int64, points int64) string {
+	t.Helper()
+	user := &po.UserInfo{UserID: userID, Username: "user" + strconv.FormatInt(userID, 10), Points: points, Level: 1}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("创建用户失败: %v", err)
+	}
+	return strconv.FormatInt(userID, 10)
+}
+
+func userPoints(t *testing.T, db *gorm.DB, userID string) int64 {
+	t.Helper()
+	var user po.UserInfo
+	if err := db.Where("user_id = ?", userID).First(&user).Error; err != nil {
+		t.Fatalf("查询用户失败: %v", err)
+	}
+	return user.Points
+}
+
+// lotRemaining 用户所有批次的剩余积分之和
+func lotRemaining(t *testing.T, db *gorm.DB, userID string) int64 {
+	t.Helper()
+	var total int64
+	if err := db.Model(&po.PointRecord{}).
+		Select("COALESCE(SUM(remaining), 0)").
+		Where("user_id = ?", userID).
+		Scan(&total).Error; err != nil {
+		t.Fatalf("查询批次失败: %v", err)
+	}
+	return total
+}
+
+// withLifetime 测试期间设置积分有效期
+func withLifetime(t *testing.T, months int) {
+	t.Helper()
+	SetPointLifetime(months)
+	t.Cleanup(func() { SetPointLifetime(0) })
+}
+
+func addPoints(t *testing.T, repo *PointRepositoryImpl, userID string, points int64) *po.PointRecord {
+	t.Helper()
+	record, err := repo.AddPointsAndExperience(context.Background(), po.PointChange{
+		UserID: userID,
+		Points: points,
+		Reason: "测试",
+	})
+	if err != nil {
+		t.Fatalf("变更积分 %d 失败: %v", points, err)
+	}
+	return record
+}
+
+func TestConsumeLotsLegacyBalanceFirst(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewPointRepository(db)
+	withLifetime(t, 12)
+	userID := createUser(t, db, 1001, 50)
+
+	lot := addPoints(t, repo, userID, 100)
+	if lot.Remaining != 100 || lot.ExpiresAt == nil {
+		t.Fatalf("收入记录应作为批次: remaining=%d expires_at=%v", lot.Remaining, lot.ExpiresAt)
+	}
+
+	// 先扣历史积分，批次不变
+	addPoints(t, repo, userID, -30)
+	if got := lotRemaining(t, db, userID); got != 100 {
+		t.Fatalf("历史积分足够时不应扣批次，批次剩余 %d", got)
+	}
+
+	// 历史积分只剩 20，超出部分扣批次
+	addPoints(t, repo, userID, -50)
+	if got := lotRemaining(t, db, userID); got != 70 {
+		t.Fatalf("批次剩余 = %d, want 70", got)
+	}
+	if got := userPoints(t, db, userID); got != 70 {
+		t.Fatalf("积分 = %d, want 70", got)
+	}
+}
+
+func TestConsumeLotsOldestFirst(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewPointRepository(db)
+	withLifetime(t, 12)
+	userID := createUser(t, db, 1002, 0)
+
+	first := addPoints(t, repo, userID, 30)
+	second := addPoints(t, repo, userID, 40)
+	addPoints(t, repo, userID, -50)
+
+	for _, tc := range []struct {
+		id   int64
+		want int64
+	}{{first.ID, 0}, {second.ID, 20}} {
+		var lot po.PointRecord
+		if err := db.First(&lot, tc.id).Error; err != nil {
+			t.Fatal(err)
+		}
+		if lot.Remaining != tc.want {
+			t.Errorf("批次 %d 剩余 %d, want %d", tc.id, lot.Remaining, tc.want)
+		}
+	}
+}
+
+func TestExpireLots(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewPointRepository(db)
+	withLifetime(t, 1)
+	ctx := context.Background()
+
+	// 用户 A：历史积分 10，批次 100 已用掉 40
+	userA := createUser(t, db, 2001, 10)
+	addPoints(t, repo, userA, 100)
+	addPoints(t, repo, userA, -50)
+	// 用户 B：批次全部用完，没有需要过期的积分
+	userB := createUser(t, db, 2002, 0)
+	addPoints(t, repo, userB, 20)
+	addPoints(t, repo, userB, -20)
+	// 用户 C：批次还没过期
+	userC := createUser(t, db, 2003, 0)
+
+	// 一个月后 A、B 的批次过期，之后 C 获得的积分在更晚的时候过期
+	now := time.Now().AddDate(0, 1, 1)
+	withLifetime(t, 12)
+	addPoints(t, repo, userC, 30)
+
+	n, err := repo.ExpireLots(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("ExpireLots: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("处理用户数 = %d, want 1", n)
+	}
+	if got := userPoints(t, db, userA); got != 0 {
+		t.Errorf("A 积分 = %d, want 0", got)
+	}
+	if got := lotRemaining(t, db, userA); got != 0 {
+		t.Errorf("A 批次剩余 = %d, want 0", got)
+	}
+	var expired []po.PointRecord
+	if err := db.Where("reason = ?", po.ReasonExpired).Find(&expired).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(expired) != 1 || expired[0].UserID != userA || expired[0].Points != -60 {
+		t.Fatalf("过期记录 = %+v, want A 过期 60", expired)
+	}
+	if got := userPoints(t, db, userB); got != 0 {
+		t.Errorf("B 积分 = %d, want 0", got)
+	}
+	if got := userPoints(t, db, userC); got != 30 {
+		t.Errorf("C 积分 = %d, want 30", got)
+	}
+
+	// 再次清理没有需要处理的用户
+	n, err = repo.ExpireLots(ctx, now, 10)
+	if err != nil || n != 0 {
+		t.Fatalf("重复清理 = %d, %v, want 0", n, err)
+	}
+}
+
+func TestExpireLotsBatches(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewPointRepository(db)
+	withLifetime(t, 1)
+	ctx := context.Background()
+
+	for i := int64(0); i < 5; i++ {
+		addPoints(t, repo, createUser(t, db, 3000+i, 0), 10)
+	}
+	now := time.Now().AddDate(0, 2, 0)
+
+	var counts []int
+	for {
+		n, err := repo.ExpireLots(ctx, now, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts = append(counts, n)
+		if n < 2 {
+			break
+		}
+	}
+	if len(counts) != 3 || counts[0] != 2 || counts[1] != 2 || counts[2] != 1 {
+		t.Fatalf("每批处理用户数 = %v, want [2 2 1]", counts)
+	}
+
+	var total int64
+	if err := db.Model(&po.UserInfo{}).Select("COALESCE(SUM(points), 0)").Scan(&total).Error; err != nil {
+		t.Fatal(err)
+	}
+	if total != 0 {
+		t.Fatalf("过期后积分总和 = %d, want 0", total)
+	}
+}
+
+func TestSumExpiringPoints(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewPointRepository(db)
+	withLifetime(t, 1)
+	userID := createUser(t, db, 4001, 0)
+	addPoints(t, repo, userID, 25)
+
+	total, earliest, err := repo.SumExpiringPoints(context.Background(), userID, time.Now().AddDate(0, 2, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 25 || earliest == nil {
+		t.Fatalf("即将过期 = %d, %v, want 25", total, earliest)
+	}
+
+	total, earliest, err = repo.SumExpiringPoints(context.Background(), userID, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 0 || earliest != nil {
+		t.Fatalf("当前没有即将过期的积分，得到 %d, %v", total, earliest)
+	}
+}
+
+// lotExpiresAtOf 查询记录的过期时间
+func lotExpiresAtOf(t *testing.T, db *gorm.DB, id int64) *time.Time {
+	t.Helper()
+	var record po.PointRecord
+	if err := db.First(&record, id).Error; err != nil {
+		t.Fatal(err)
+	}
+	return record.ExpiresAt
+}
+
+// sameExpiry 比较过期时间，忽略数据库的时间精度
+func sameExpiry(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Sub(*b).Abs() < time.Second
+}
+
+func TestTransferKeepsLotExpiry(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewPointRepository(db)
+	ctx := context.Background()
+	withLifetime(t, 1)
+	userA := createUser(t, db, 6001, 0)
+	userB := createUser(t, db, 6002, 0)
+	lot := addPoints(t, repo, userA, 100)
+
+	// 之后获得的积分有效期更长，但来回转账不能借此延长原批次的有效期
+	withLifetime(t, 12)
+	for _, transfer := range []struct{ from, to string }{{userA, userB}, {userB, userA}} {
+		transferID, err := repo.TransferPoints(ctx, transfer.from, transfer.to, 60, 0)
+		if err != nil {
+			t.Fatalf("TransferPoints: %v", err)
+		}
+		var in po.PointRecord
+		if err := db.Where("transfer_id = ? AND reason = ?", transferID, po.ReasonTransferIn).First(&in).Error; err != nil {
+			t.Fatal(err)
+		}
+		if !sameExpiry(in.ExpiresAt, lot.ExpiresAt) {
+			t.Fatalf("转入批次过期时间 = %v, want %v", in.ExpiresAt, lot.ExpiresAt)
+		}
+	}
+
+	if _, err := repo.ExpireLots(ctx, lot.ExpiresAt.Add(time.Second), 10); err != nil {
+		t.Fatal(err)
+	}
+	if a, b := userPoints(t, db, userA), userPoints(t, db, userB); a != 0 || b != 0 {
+		t.Fatalf("原批次到期后积分 A=%d B=%d, want 0", a, b)
+	}
+}
+
+func TestReversedDebitKeepsLotExpiry(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewPointRepository(db)
+	ctx := context.Background()
+	withLifetime(t, 1)
+	userID := createUser(t, db, 6003, 30)
+	lot := addPoints(t, repo, userID, 100)
+	withLifetime(t, 12)
+
+	tests := []struct {
+		name   string
+		points int64
+		want   *time.Time
+	}{
+		// 只扣了不会过期的历史积分，退回的积分也不过期
+		{name: "扣历史积分", points: -30, want: nil},
+		{name: "扣批次", points: -40, want: lot.ExpiresAt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			debit := addPoints(t, repo, userID, tt.points)
+			reversal, err := repo.ReversePointRecord(ctx, debit.ID, "9")
+			if err != nil {
+				t.Fatalf("ReversePointRecord: %v", err)
+			}
+			if got := lotExpiresAtOf(t, db, reversal.ID); !sameExpiry(got, tt.want) {
+				t.Fatalf("退回批次过期时间 = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,8 +3,15 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trancecho/mundo-points-system/pkg/metrics"
 	"github.com/trancecho/mundo-points-system/po"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PointRepositoryImpl struct {
@@ -18,67 +25,316 @@ func NewPointRepository(db *gorm.DB) *PointRepositoryImpl {
 	}
 }
 
-// AddPointsAndExperience 添加积分和经验值
-func (r *PointRepositoryImpl) AddPointsAndExperience(ctx context.Context, userID string, points int64, experience int64, reason string) error {
+// AddPointsAndExperience 添加积分和经验值，返回写入的积分记录
+// change.IdempotencyKey 非空时，同一个键只会生效一次，重放的请求返回首次写入的记录，内容不同时返回 po.ErrIdempotencyKeyConflict
+// change.DailyLimit 大于 0 时超出当天额度的收入不发放，积分和经验都没有发放时返回 po.ErrEarnLimitExceeded
+func (r *PointRepositoryImpl) AddPointsAndExperience(ctx context.Context, change po.PointChange) (*po.PointRecord, error) {
 	// 开启事务
 	tx := r.db.WithContext(ctx).Begin()
 	if tx.Error != nil {
-		return tx.Error
+		return nil, tx.Error
+	}
+
+	// 先锁定用户行，同一用户的幂等检查和额度检查串行执行，多实例部署时同样有效
+	if err := lockUser(tx, change.UserID); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// 检查幂等键是否已经处理过
+	if change.IdempotencyKey != "" {
+		existing, err := findIdempotencyRecord(tx, change)
+		if err != nil || existing != nil {
+			tx.Rollback()
+			return existing, err
+		}
+	}
+
+	// 检查每日获取额度，超出部分不发放
+	points, err := capDailyEarn(tx, change.UserID, change.Reason, change.Points, change.DailyLimit)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if points == 0 && change.Points > 0 && change.Experience == 0 {
+		tx.Rollback()
+		return nil, po.ErrEarnLimitExceeded
 	}
 
 	// 记录积分变更
 	pointRecord := &po.PointRecord{
-		UserID:     userID,
+		UserID:     change.UserID,
 		Points:     points,
-		Experience: experience,
-		Reason:     reason,
+		Experience: change.Experience,
+		Reason:     change.Reason,
 	}
-	if err := tx.Create(pointRecord).Error; err != nil {
+	if change.IdempotencyKey != "" {
+		pointRecord.IdempotencyKey = &change.IdempotencyKey
+		pointRecord.RequestHash = change.RequestHash()
+	}
+	if err := applyPointRecord(tx, pointRecord); err != nil {
 		tx.Rollback()
-		return err
+		// 其他用户的请求同时使用了同一个幂等键，撞上唯一约束
+		if errors.Is(err, gorm.ErrDuplicatedKey) && change.IdempotencyKey != "" {
+			existing, findErr := findIdempotencyRecord(r.db.WithContext(ctx), change)
+			if findErr != nil || existing != nil {
+				return existing, findErr
+			}
+		}
+		return nil, err
+	}
+
+	if err := commitPointRecords(tx, pointRecord); err != nil {
+		return nil, err
 	}
 
-	// 更新用户积分和经验
-	result := tx.Model(&po.UserInfo{}).
+	return pointRecord, nil
+}
+
+// lockUser 锁定用户行，用户不存在时返回 po.ErrUserNotFound
+// 所有积分变更都会更新用户行，锁定后同一用户的其他变更要等本事务结束，之后的查询能看到它们写入的记录
+func lockUser(tx *gorm.DB, userID string) error {
+	var user po.UserInfo
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
 		Where("user_id = ?", userID).
-		Updates(map[string]interface{}{
-			"points":     gorm.Expr("points + ?", points),
-			"experience": gorm.Expr("experience + ?", experience),
-		})
+		First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return po.ErrUserNotFound
+	}
+	return err
+}
 
+// capDailyEarn 按每日额度返回本次实际可以获得的积分，调用前需已锁定用户行
+// 扣减积分和 dailyLimit 小于等于 0 时不受限制
+func capDailyEarn(tx *gorm.DB, userID string, reason string, points int64, dailyLimit int64) (int64, error) {
+	if points <= 0 || dailyLimit <= 0 {
+		return points, nil
+	}
+
+	var result struct {
+		Total int64
+	}
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if err := tx.Model(&po.PointRecord{}).
+		Select("COALESCE(SUM(points), 0) as total").
+		Where("user_id = ? AND reason = ? AND points > 0 AND created_at >= ?", userID, reason, today).
+		Scan(&result).Error; err != nil {
+		return 0, err
+	}
+
+	return max(min(points, dailyLimit-result.Total), 0), nil
+}
+
+// applyPointRecord 在事务内更新用户积分和经验并写入积分记录
+// 所有积分变更都经过这里，由它维护积分批次；扣减积分时余额检查和扣减在同一条 UPDATE 中完成，并发扣减不会把余额扣成负数
+// 收入记录作为新批次，从现在起算有效期
+func applyPointRecord(tx *gorm.DB, record *po.PointRecord) error {
+	return applyPointRecordExpiring(tx, record, lotExpiresAt(time.Now()))
+}
+
+// applyPointRecordExpiring 与 applyPointRecord 相同，但收入记录的批次在 expiresAt 过期，为空表示不过期
+// 转入和冲正支出得到的积分沿用支出时所扣批次的过期时间，不能通过来回转账或退款延长有效期
+func applyPointRecordExpiring(tx *gorm.DB, record *po.PointRecord, expiresAt *time.Time) error {
+	if err := updateBalance(tx, record); err != nil {
+		return err
+	}
+
+	// 收入记录作为一个积分批次，支出按先进先出扣减批次余额；用户行已被上面的 UPDATE 锁定
+	if record.Points > 0 {
+		record.Remaining = record.Points
+		record.ExpiresAt = expiresAt
+	} else if record.Points < 0 {
+		// 支出记录保存所扣批次中最早的过期时间，供转入方和冲正时沿用
+		consumedExpiresAt, err := consumeLots(tx, record.UserID, -record.Points)
+		if err != nil {
+			return err
+		}
+		record.ExpiresAt = consumedExpiresAt
+	}
+
+	return tx.Create(record).Error
+}
+
+// updateBalance 按积分记录更新用户积分和经验，不写入记录也不维护批次
+func updateBalance(tx *gorm.DB, record *po.PointRecord) error {
+	query := tx.Model(&po.UserInfo{}).Where("user_id = ?", record.UserID)
+	// 不对扣减量取反，record.Points 为 math.MinInt64 时取反会溢出
+	if record.Points < 0 {
+		query = query.Where("points + ? >= 0", record.Points)
+	}
+	result := query.Updates(map[string]interface{}{
+		"points":     gorm.Expr("points + ?", record.Points),
+		"experience": gorm.Expr("experience + ?", record.Experience),
+	})
 	if result.Error != nil {
-		tx.Rollback()
 		return result.Error
 	}
 
 	if result.RowsAffected == 0 {
+		// 区分用户不存在和积分不足
+		var count int64
+		if err := tx.Model(&po.UserInfo{}).Where("user_id = ?", record.UserID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return po.ErrUserNotFound
+		}
+		// 变更量为 0 时 MySQL 也可能返回 0 行受影响，只有扣减积分才算积分不足
+		if record.Points < 0 {
+			return po.ErrPointsInsufficient
+		}
+	}
+	return nil
+}
+
+// TransferPoints 在同一事务内从 fromUserID 扣除积分并转给 toUserID，返回转账ID
+// dailyLimit 为转出方当天累计转出的上限，小于等于 0 表示不限
+func (r *PointRepositoryImpl) TransferPoints(ctx context.Context, fromUserID string, toUserID string, points int64, dailyLimit int64) (string, error) {
+	if fromUserID == toUserID || points <= 0 {
+		return "", errors.New("无效的转账请求")
+	}
+
+	// 开启事务
+	tx := r.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return "", tx.Error
+	}
+
+	// 按 user_id 顺序锁定双方，避免相向转账互相等待造成死锁，同时确认双方都存在
+	var users []po.UserInfo
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id IN ?", []string{fromUserID, toUserID}).
+		Order("user_id").
+		Find(&users).Error; err != nil {
 		tx.Rollback()
-		return errors.New("用户不存在")
+		return "", err
+	}
+	if len(users) != 2 {
+		tx.Rollback()
+		return "", po.ErrUserNotFound
+	}
+
+	// 检查当天累计转出是否超出限额，转出方已加锁，并发转账不会同时通过检查
+	if dailyLimit > 0 {
+		var result struct {
+			Total int64
+		}
+		now := time.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		if err := tx.Model(&po.PointRecord{}).
+			Select("COALESCE(-SUM(points), 0) as total").
+			Where("user_id = ? AND reason = ? AND created_at >= ?", fromUserID, po.ReasonTransferOut, today).
+			Scan(&result).Error; err != nil {
+			tx.Rollback()
+			return "", err
+		}
+		if result.Total+points > dailyLimit {
+			tx.Rollback()
+			return "", po.ErrTransferLimitExceeded
+		}
 	}
 
-	return tx.Commit().Error
+	transferID := uuid.NewString()
+	records := []*po.PointRecord{
+		{UserID: fromUserID, Points: -points, Reason: po.ReasonTransferOut, TransferID: transferID},
+		{UserID: toUserID, Points: points, Reason: po.ReasonTransferIn, TransferID: transferID},
+	}
+	if err := applyPointRecord(tx, records[0]); err != nil {
+		tx.Rollback()
+		return "", err
+	}
+	// 转入的积分沿用转出时所扣批次的过期时间
+	if err := applyPointRecordExpiring(tx, records[1], records[0].ExpiresAt); err != nil {
+		tx.Rollback()
+		return "", err
+	}
+
+	return transferID, commitPointRecords(tx, records...)
+}
+
+// commitPointRecords 提交事务，成功后统计事务内写入的积分变更
+func commitPointRecords(tx *gorm.DB, records ...*po.PointRecord) error {
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	for _, record := range records {
+		metrics.ObservePointChange(reasonLabel(record.Reason), record.Points)
+	}
+	return nil
 }
 
-// RecordLike 记录点赞信息
-func (r *PointRepositoryImpl) RecordLike(ctx context.Context, userID string, postID string, targetUserID string) error {
+// reasonLabel 积分原因对应的指标标签，系统原因原样使用，其余调用方传入的原因统一记为 other
+func reasonLabel(reason string) string {
+	if slices.Contains(po.SystemReasons, reason) {
+		return reason
+	}
+	return metrics.ReasonOther
+}
+
+// findIdempotencyRecord 查找使用 change 的幂等键写入的记录，没有时返回 nil
+// 键被其他用户或内容不同的请求占用时返回 ErrIdempotencyKeyConflict
+func findIdempotencyRecord(db *gorm.DB, change po.PointChange) (*po.PointRecord, error) {
+	var existing po.PointRecord
+	err := db.Where("idempotency_key = ?", change.IdempotencyKey).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if existing.RequestHash == "" {
+		// 旧版本写入的记录没有摘要，按记录内容比较
+		if existing.UserID != change.UserID || existing.Points != change.Points ||
+			existing.Experience != change.Experience || existing.Reason != change.Reason {
+			return nil, po.ErrIdempotencyKeyConflict
+		}
+	} else if existing.RequestHash != change.RequestHash() {
+		return nil, po.ErrIdempotencyKeyConflict
+	}
+	return &existing, nil
+}
+
+// RecordLike 记录点赞信息，并通过积分记录给被点赞者发放积分和经验
+// 取消过的点赞再次点赞时只恢复点赞记录，不再给被点赞者加分，防止反复点赞刷分
+// dailyLimit 为被点赞者每天因被点赞获得积分的上限，超出后点赞仍然成功但不再加分
+func (r *PointRepositoryImpl) RecordLike(ctx context.Context, userID string, postID string, targetUserID string, points int64, experience int64, dailyLimit int64) error {
 	// 开启事务
 	tx := r.db.WithContext(ctx).Begin()
 	if tx.Error != nil {
 		return tx.Error
 	}
 
-	// 检查是否已经点赞
-	var count int64
-	if err := tx.Model(&po.LikeRecord{}).
-		Where("user_id = ? AND post_id = ?", userID, postID).
-		Count(&count).Error; err != nil {
+	// 锁定被点赞者，额度检查和发放积分之间不会有其他请求插入
+	if err := lockUser(tx, targetUserID); err != nil {
 		tx.Rollback()
 		return err
 	}
 
-	if count > 0 {
+	// 检查是否已经点赞，包括已取消的点赞
+	var existing po.LikeRecord
+	err := tx.Unscoped().
+		Where("user_id = ? AND post_id = ?", userID, postID).
+		Order("id DESC").
+		First(&existing).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		tx.Rollback()
-		return errors.New("已经点过赞")
+		return err
+	}
+
+	if err == nil {
+		if !existing.DeletedAt.Valid {
+			tx.Rollback()
+			return po.ErrAlreadyLiked
+		}
+
+		// 恢复之前取消的点赞，不再加分
+		if err := tx.Unscoped().Model(&existing).Update("deleted_at", nil).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		return tx.Commit().Error
 	}
 
 	// 记录点赞
@@ -86,21 +342,325 @@ func (r *PointRepositoryImpl) RecordLike(ctx context.Context, userID string, pos
 		UserID:       userID,
 		PostID:       postID,
 		TargetUserID: targetUserID,
+		Rewarded:     true,
 	}
 	if err := tx.Create(likeRecord).Error; err != nil {
 		tx.Rollback()
+		// 同一用户并发点赞同一帖子，另一个请求已经写入
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return po.ErrAlreadyLiked
+		}
 		return err
 	}
 
-	// 给被点赞者加积分
-	result := tx.Model(&po.UserInfo{}).
-		Where("user_id = ?", targetUserID).
-		Update("points", gorm.Expr("points + ?", 1)) // 点赞默认加1分
+	// 给被点赞者加积分和经验
+	points, err = capDailyEarn(tx, targetUserID, po.ReasonLiked, points, dailyLimit)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	pointRecord := &po.PointRecord{
+		UserID:        targetUserID,
+		Points:        points,
+		Experience:    experience,
+		Reason:        po.ReasonLiked,
+		RelatedUserID: userID,
+		RefID:         postID,
+	}
+	if err := applyPointRecord(tx, pointRecord); err != nil {
+		tx.Rollback()
+		return err
+	}
 
-	if result.Error != nil {
+	return commitPointRecords(tx, pointRecord)
+}
+
+// UnrecordLike 取消点赞，软删除点赞记录并在同一事务内收回被点赞者的积分和经验，返回被点赞者ID
+// 经验总是全部收回；被点赞者积分已不足时只收回剩余的积分，不把余额扣成负数
+func (r *PointRepositoryImpl) UnrecordLike(ctx context.Context, userID string, postID string) (string, error) {
+	// 先查出被点赞者，事务内按 用户行 -> 点赞记录 的顺序加锁，与 RecordLike 一致，避免并发点赞和取消时死锁
+	var found po.LikeRecord
+	err := r.db.WithContext(ctx).
+		Select("target_user_id").
+		Where("user_id = ? AND post_id = ?", userID, postID).
+		First(&found).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", po.ErrLikeNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// 开启事务
+	tx := r.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return "", tx.Error
+	}
+
+	var target po.UserInfo
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "points").
+		Where("user_id = ?", found.TargetUserID).
+		First(&target).Error
+	targetExists := err == nil
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		tx.Rollback()
-		return result.Error
+		return "", err
+	}
+
+	var likeRecord po.LikeRecord
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND post_id = ?", userID, postID).
+		First(&likeRecord).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return "", po.ErrLikeNotFound
+	}
+	if err != nil {
+		tx.Rollback()
+		return "", err
+	}
+
+	// 收回点赞奖励，被点赞者已删除时只取消点赞
+	var applied []*po.PointRecord
+	if likeRecord.Rewarded && targetExists {
+		var original po.PointRecord
+		err := tx.Where("user_id = ? AND reason = ? AND related_user_id = ? AND ref_id = ?",
+			likeRecord.TargetUserID, po.ReasonLiked, userID, postID).
+			Order("id DESC").
+			First(&original).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 旧版本的点赞没有积分记录，当时固定奖励 1 积分
+			original = po.PointRecord{Points: 1}
+		} else if err != nil {
+			tx.Rollback()
+			return "", err
+		}
+
+		reversal := &po.PointRecord{
+			UserID:        likeRecord.TargetUserID,
+			Points:        -min(original.Points, max(target.Points, 0)),
+			Experience:    -original.Experience,
+			Reason:        po.ReasonUnliked,
+			RelatedUserID: userID,
+			RefID:         postID,
+		}
+		if err := applyPointRecord(tx, reversal); err != nil {
+			tx.Rollback()
+			return "", err
+		}
+		applied = append(applied, reversal)
+	}
+	if likeRecord.Rewarded {
+		if err := tx.Model(&likeRecord).Update("rewarded", false).Error; err != nil {
+			tx.Rollback()
+			return "", err
+		}
+	}
+
+	// 软删除点赞记录
+	if err := tx.Delete(&likeRecord).Error; err != nil {
+		tx.Rollback()
+		return "", err
 	}
 
-	return tx.Commit().Error
+	return likeRecord.TargetUserID, commitPointRecords(tx, applied...)
+}
+
+// ListPointRecords 按条件分页查询积分记录，按 ID 倒序返回
+func (r *PointRepositoryImpl) ListPointRecords(ctx context.Context, filter po.PointRecordFilter) ([]*po.PointRecord, error) {
+	query := r.db.WithContext(ctx).
+		Model(&po.PointRecord{}).
+		Where("user_id = ?", filter.UserID)
+
+	if filter.BeforeID > 0 {
+		query = query.Where("id < ?", filter.BeforeID)
+	}
+	if !filter.StartTime.IsZero() {
+		query = query.Where("created_at >= ?", filter.StartTime)
+	}
+	if !filter.EndTime.IsZero() {
+		query = query.Where("created_at < ?", filter.EndTime)
+	}
+	if filter.Reason != "" {
+		query = query.Where("reason = ?", filter.Reason)
+	}
+	switch filter.Direction {
+	case po.PointDirectionEarn:
+		query = query.Where("points > 0")
+	case po.PointDirectionSpend:
+		query = query.Where("points < 0")
+	}
+
+	var records []*po.PointRecord
+	err := query.Order("id DESC").Limit(filter.Limit).Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// nonReversibleReasons 由各自流程维护的积分记录，不能单独冲正
+var nonReversibleReasons = map[string]bool{
+	po.ReasonTransferOut: true, // 转账需要双方同时冲正
+	po.ReasonTransferIn:  true,
+	po.ReasonLiked:       true, // 通过取消点赞收回
+	po.ReasonUnliked:     true,
+	po.ReasonReversal:    true,
+	po.ReasonExpired:     true, // 过期的批次已经作废，需要补偿时由管理员调整
+	po.ReasonAdminAdjust: true, // 管理员调整需要再次调整并记录工单
+}
+
+// ReversePointRecord 写入一条与原记录积分和经验相反的冲正记录，返回冲正记录，operatorID 记入审计记录
+// 一条记录只能冲正一次。冲正收入时只收回该批次剩余的积分，已使用或过期的部分不再收回，
+// 启用批次之前的收入按剩余的历史积分收回，
+// 剩余积分和经验都为 0 时返回 po.ErrPointsInsufficient；冲正兑换扣除的积分时同时取消订单并恢复库存
+func (r *PointRepositoryImpl) ReversePointRecord(ctx context.Context, recordID int64, operatorID string) (*po.PointRecord, error) {
+	// 先查出记录所属用户，事务内按 用户行 -> 原记录 -> 兑换订单 -> 商品 的顺序加锁
+	// 其他积分变更同样先锁用户行，RedeemItem 也是先锁用户行再锁商品，不会与冲正互相等待
+	var owner po.PointRecord
+	err := r.db.WithContext(ctx).Select("user_id").Where("id = ?", recordID).First(&owner).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, po.ErrPointRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 开启事务
+	tx := r.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	if err := lockUser(tx, owner.UserID); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// 锁定原记录，并发冲正同一条记录时串行执行，收入记录的批次余额也不会被同时扣减
+	var original po.PointRecord
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", recordID).
+		First(&original).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if nonReversibleReasons[original.Reason] || original.ReversesID != nil {
+		tx.Rollback()
+		return nil, po.ErrRecordNotReversible
+	}
+
+	var count int64
+	if err := tx.Model(&po.PointRecord{}).Where("reverses_id = ?", original.ID).Count(&count).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if count > 0 {
+		tx.Rollback()
+		return nil, po.ErrAlreadyReversed
+	}
+
+	reversal := &po.PointRecord{
+		UserID:        original.UserID,
+		Points:        -original.Points,
+		Experience:    -original.Experience,
+		Reason:        po.ReasonReversal,
+		RelatedUserID: original.RelatedUserID,
+		RefID:         original.RefID,
+		ReversesID:    &original.ID,
+	}
+	if original.Points > 0 {
+		err = reverseCredit(tx, &original, reversal)
+	} else {
+		if original.Reason == po.ReasonRedeem {
+			err = cancelRedeemOrder(tx, original.RefID)
+		}
+		// 退回的积分沿用支出时所扣批次的过期时间
+		if err == nil {
+			err = applyPointRecordExpiring(tx, reversal, original.ExpiresAt)
+		}
+	}
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, po.ErrAlreadyReversed
+		}
+		return nil, err
+	}
+
+	audit := &po.AdminAuditRecord{
+		OperatorID:    operatorID,
+		UserID:        reversal.UserID,
+		Points:        reversal.Points,
+		Experience:    reversal.Experience,
+		Reason:        fmt.Sprintf("%s %d", po.ReasonReversal, original.ID),
+		PointRecordID: reversal.ID,
+	}
+	if err := tx.Create(audit).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := commitPointRecords(tx, reversal); err != nil {
+		return nil, err
+	}
+
+	return reversal, nil
+}
+
+// reverseCredit 冲正收入记录，只从原记录的批次中收回剩余的积分，不影响其他批次
+// 启用批次之前写入的收入没有批次，从同样没有批次的历史积分中收回
+func reverseCredit(tx *gorm.DB, original *po.PointRecord, reversal *po.PointRecord) error {
+	lotted := original.Remaining > 0 || original.ExpiresAt != nil
+	if lotted {
+		reversal.Points = -original.Remaining
+	} else {
+		// 批次已用完的不过期批次也会走到这里，此时历史积分一定已经为 0（扣减时先扣历史积分），不会多收回
+		legacy, err := legacyPoints(tx, original.UserID)
+		if err != nil {
+			return err
+		}
+		reversal.Points = -min(original.Points, max(legacy, 0))
+	}
+	if reversal.Points == 0 && reversal.Experience == 0 {
+		return po.ErrPointsInsufficient
+	}
+
+	if err := updateBalance(tx, reversal); err != nil {
+		return err
+	}
+	if lotted {
+		if err := tx.Model(original).Update("remaining", 0).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Create(reversal).Error
+}
+
+// cancelRedeemOrder 取消兑换订单并恢复商品库存，订单已取消时返回 po.ErrAlreadyReversed
+func cancelRedeemOrder(tx *gorm.DB, orderID string) error {
+	var order po.RedeemOrder
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ?", orderID).
+		First(&order).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// 没有对应订单的兑换记录只冲正积分
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if order.CancelledAt != nil {
+		return po.ErrAlreadyReversed
+	}
+
+	if err := tx.Model(&order).Update("cancelled_at", time.Now()).Error; err != nil {
+		return err
+	}
+	return tx.Model(&po.RewardItem{}).
+		Where("id = ?", order.ItemID).
+		Update("stock", gorm.Expr("stock + ?", order.Quantity)).Error
 }
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/trancecho/mundo-points-system/pkg/testdb"
+	"github.com/trancecho/mundo-points-system/po"
+)
+
+func TestDebitNeverBelowZero(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewPointRepository(db)
+	userID := createUser(t, db, 7001, 100)
+
+	for _, points := range []int64{-101, math.MinInt64, math.MinInt64 + 1} {
+		_, err := repo.AddPointsAndExperience(context.Background(), po.PointChange{
+			UserID: userID,
+			Points: points,
+			Reason: "测试",
+		})
+		if !errors.Is(err, po.ErrPointsInsufficient) {
+			t.Fatalf("扣减 %d 返回 %v, want ErrPointsInsufficient", points, err)
+		}
+	}
+	if got := userPoints(t, db, userID); got != 100 {
+		t.Fatalf("积分 = %d, want 100", got)
+	}
+
+	addPoints(t, repo, userID, -100)
+	if got := userPoints(t, db, userID); got != 0 {
+		t.Fatalf("积分 = %d, want 0", got)
+	}
+}
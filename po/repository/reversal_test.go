@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/trancecho/mundo-points-system/pkg/testdb"
+	"github.com/trancecho/mundo-points-system/po"
+)
+
+func TestReverseCreditTakesOnlyRemaining(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewPointRepository(db)
+	ctx := context.Background()
+	userID := createUser(t, db, 5001, 0)
+
+	credit := addPoints(t, repo, userID, 100)
+	addPoints(t, repo, userID, -70)
+
+	reversal, err := repo.ReversePointRecord(ctx, credit.ID, "9")
+	if err != nil {
+		t.Fatalf("ReversePointRecord: %v", err)
+	}
+	if reversal.Points != -30 {
+		t.Fatalf("冲正积分 = %d, want -30", reversal.Points)
+	}
+	if got := userPoints(t, db, userID); got != 0 {
+		t.Fatalf("冲正后积分 = %d, want 0", got)
+	}
+
+	var audit po.AdminAuditRecord
+	if err := db.Where("point_record_id = ?", reversal.ID).First(&audit).Error; err != nil {
+		t.Fatalf("没有写入审计记录: %v", err)
+	}
+	if audit.OperatorID != "9" || audit.UserID != userID {
+		t.Fatalf("审计记录 = %+v", audit)
+	}
+
+	if _, err := repo.ReversePointRecord(ctx, credit.ID, "9"); !errors.Is(err, po.ErrAlreadyReversed) {
+		t.Fatalf("重复冲正返回 %v, want ErrAlreadyReversed", err)
+	}
+}
+
+func TestReverseFullyUsedCredit(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewPointRepository(db)
+	userID := createUser(t, db, 5002, 0)
+
+	credit := addPoints(t, repo, userID, 10)
+	addPoints(t, repo, userID, -10)
+
+	if _, err := repo.ReversePointRecord(context.Background(), credit.ID, "9"); !errors.Is(err, po.ErrPointsInsufficient) {
+		t.Fatalf("批次已用完时返回 %v, want ErrPointsInsufficient", err)
+	}
+}
+
+func TestReversePreLotCredit(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewPointRepository(db)
+	withLifetime(t, 12)
+
+	tests := []struct {
+		name   string
+		legacy int64
+		want   int64
+	}{
+		{name: "历史积分足够", legacy: 100, want: -60},
+		{name: "历史积分不足时只收回剩余部分", legacy: 20, want: -20},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := createUser(t, db, int64(5010+i), tt.legacy)
+			// 启用批次之前写入的收入记录没有批次余额和过期时间
+			credit := &po.PointRecord{UserID: userID, Points: 60, Reason: "测试"}
+			if err := db.Create(credit).Error; err != nil {
+				t.Fatal(err)
+			}
+			// 启用批次之后的收入不受影响
+			addPoints(t, repo, userID, 50)
+
+			reversal, err := repo.ReversePointRecord(context.Background(), credit.ID, "9")
+			if err != nil {
+				t.Fatalf("ReversePointRecord: %v", err)
+			}
+			if reversal.Points != tt.want {
+				t.Fatalf("冲正积分 = %d, want %d", reversal.Points, tt.want)
+			}
+			if got := lotRemaining(t, db, userID); got != 50 {
+				t.Fatalf("批次剩余 = %d, want 50", got)
+			}
+			if got := userPoints(t, db, userID); got != tt.legacy+50+tt.want {
+				t.Fatalf("冲正后积分 = %d, want %d", got, tt.legacy+50+tt.want)
+			}
+		})
+	}
+}
+
+func TestReverseNonReversibleReasons(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewPointRepository(db)
+	ctx := context.Background()
+	userID := createUser(t, db, 5003, 100)
+
+	audit := &po.AdminAuditRecord{OperatorID: "9", UserID: userID, Points: 5, Reason: "补偿", Ticket: "T-1"}
+	if err := repo.AdminAdjustPoints(ctx, audit); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.ReversePointRecord(ctx, audit.PointRecordID, "9"); !errors.Is(err, po.ErrRecordNotReversible) {
+		t.Fatalf("冲正管理员调整返回 %v, want ErrRecordNotReversible", err)
+	}
+}
+
+func TestReverseRedeemCancelsOrder(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewPointRepository(db)
+	shop := NewShopRepository(db)
+	ctx := context.Background()
+	userID := createUser(t, db, 5004, 100)
+
+	item := &po.RewardItem{Name: "贴纸", Price: 30, Stock: 5, PerUserLimit: 2, Active: true}
+	if err := shop.CreateRewardItem(ctx, item); err != nil {
+		t.Fatal(err)
+	}
+	order, err := shop.RedeemItem(ctx, userID, item.ID, 2)
+	if err != nil {
+		t.Fatalf("RedeemItem: %v", err)
+	}
+	var debit po.PointRecord
+	if err := db.Where("ref_id = ? AND reason = ?", order.OrderID, po.ReasonRedeem).First(&debit).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.ReversePointRecord(ctx, debit.ID, "service:shop"); err != nil {
+		t.Fatalf("ReversePointRecord: %v", err)
+	}
+	if got := userPoints(t, db, userID); got != 100 {
+		t.Fatalf("冲正后积分 = %d, want 100", got)
+	}
+	var stored po.RewardItem
+	if err := db.First(&stored, item.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.Stock != 5 {
+		t.Fatalf("库存 = %d, want 5", stored.Stock)
+	}
+	var cancelled po.RedeemOrder
+	if err := db.First(&cancelled, order.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if cancelled.CancelledAt == nil {
+		t.Fatal("订单没有被取消")
+	}
+
+	// 取消的订单不计入每人限购
+	if _, err := shop.RedeemItem(ctx, userID, item.ID, 2); err != nil {
+		t.Fatalf("取消订单后再次兑换: %v", err)
+	}
+}
+
+// TestConcurrentRedeemAndReversal 同一用户同一商品并发兑换和冲正兑换，加锁顺序一致时不会死锁
+func TestConcurrentRedeemAndReversal(t *testing.T) {
+	testdb.RequireMySQL(t)
+	db := testdb.Open(t)
+	repo := NewPointRepository(db)
+	shop := NewShopRepository(db)
+	ctx := context.Background()
+	userID := createUser(t, db, 5005, 1000)
+
+	item := &po.RewardItem{Name: "贴纸", Price: 10, Stock: 100, Active: true}
+	if err := shop.CreateRewardItem(ctx, item); err != nil {
+		t.Fatal(err)
+	}
+	const orders = 20
+	debits := make([]int64, 0, orders)
+	for i := 0; i < orders; i++ {
+		order, err := shop.RedeemItem(ctx, userID, item.ID, 1)
+		if err != nil {
+			t.Fatalf("RedeemItem: %v", err)
+		}
+		var debit po.PointRecord
+		if err := db.Where("ref_id = ? AND reason = ?", order.OrderID, po.ReasonRedeem).First(&debit).Error; err != nil {
+			t.Fatal(err)
+		}
+		debits = append(debits, debit.ID)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, orders*2)
+	for _, id := range debits {
+		wg.Add(2)
+		go func(id int64) {
+			defer wg.Done()
+			if _, err := repo.ReversePointRecord(ctx, id, "9"); err != nil {
+				errs <- err
+			}
+		}(id)
+		go func() {
+			defer wg.Done()
+			if _, err := shop.RedeemItem(ctx, userID, item.ID, 1); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("并发兑换和冲正失败: %v", err)
+	}
+
+	// 冲正的订单全部取消，库存只被新的兑换占用
+	var stored po.RewardItem
+	if err := db.First(&stored, item.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.Stock != 100-orders {
+		t.Fatalf("库存 = %d, want %d", stored.Stock, 100-orders)
+	}
+	if got := userPoints(t, db, userID); got != 1000-orders*10 {
+		t.Fatalf("积分 = %d, want %d", got, 1000-orders*10)
+	}
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trancecho/mundo-points-system/po"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ShopRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewShopRepository 创建积分商城仓库实例
+func NewShopRepository(db *gorm.DB) *ShopRepositoryImpl {
+	return &ShopRepositoryImpl{
+		db: db,
+	}
+}
+
+// CreateRewardItem 新建商品
+func (r *ShopRepositoryImpl) CreateRewardItem(ctx context.Context, item *po.RewardItem) error {
+	return r.db.WithContext(ctx).Create(item).Error
+}
+
+// UpdateRewardItem 更新商品，零值字段（如下架、库存清零）同样会写入
+// 更新后重新读取商品，item 为数据库中保存的内容
+func (r *ShopRepositoryImpl) UpdateRewardItem(ctx context.Context, item *po.RewardItem) error {
+	err := r.db.WithContext(ctx).
+		Model(&po.RewardItem{}).
+		Where("id = ?", item.ID).
+		Select("name", "description", "price", "stock", "per_user_limit", "start_at", "end_at", "active", "updated_at").
+		Updates(item).Error
+	if err != nil {
+		return err
+	}
+
+	// 内容没有变化时也可能返回 0 行受影响，通过重新读取确认商品是否存在
+	err = r.db.WithContext(ctx).Where("id = ?", item.ID).First(item).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return po.ErrRewardItemNotFound
+	}
+	return err
+}
+
+// ListRewardItems 获取商品列表，availableAt 非零时只返回该时间可以兑换的商品
+func (r *ShopRepositoryImpl) ListRewardItems(ctx context.Context, availableAt time.Time) ([]*po.RewardItem, error) {
+	query := r.db.WithContext(ctx).Model(&po.RewardItem{})
+	if !availableAt.IsZero() {
+		query = query.
+			Where("active = ?", true).
+			Where("start_at IS NULL OR start_at <= ?", availableAt).
+			Where("end_at IS NULL OR end_at > ?", availableAt)
+	}
+
+	var items []*po.RewardItem
+	if err := query.Order("id ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// RedeemItem 兑换商品，在同一事务内扣除积分、扣减库存并创建订单
+func (r *ShopRepositoryImpl) RedeemItem(ctx context.Context, userID string, itemID int64, quantity int64) (*po.RedeemOrder, error) {
+	if quantity <= 0 {
+		return nil, errors.New("无效的兑换数量")
+	}
+
+	// 开启事务
+	tx := r.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	// 先锁定用户行再锁定商品，与冲正兑换记录时 用户行 -> 订单 -> 商品 的顺序一致，避免并发兑换和冲正时死锁
+	if err := lockUser(tx, userID); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// 锁定商品，同一商品的兑换串行执行，库存和每人限购的检查不会被并发请求同时通过
+	var item po.RewardItem
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", itemID).
+		First(&item).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return nil, po.ErrRewardItemNotFound
+	}
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if !item.Available(time.Now()) {
+		tx.Rollback()
+		return nil, po.ErrRewardItemUnavailable
+	}
+	if item.Stock < quantity {
+		tx.Rollback()
+		return nil, po.ErrOutOfStock
+	}
+	// 价格和数量由接口限制在合理范围内，这里防止历史数据导致总价溢出
+	if item.Price <= 0 || item.Price > math.MaxInt64/quantity {
+		tx.Rollback()
+		return nil, po.ErrRewardItemUnavailable
+	}
+
+	// 检查每人限购，已取消的订单不计入
+	if item.PerUserLimit > 0 {
+		var result struct {
+			Total int64
+		}
+		if err := tx.Model(&po.RedeemOrder{}).
+			Select("COALESCE(SUM(quantity), 0) as total").
+			Where("user_id = ? AND item_id = ? AND cancelled_at IS NULL", userID, itemID).
+			Scan(&result).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if result.Total+quantity > item.PerUserLimit {
+			tx.Rollback()
+			return nil, po.ErrRedeemLimitExceeded
+		}
+	}
+
+	// 扣减库存
+	if err := tx.Model(&item).Update("stock", gorm.Expr("stock - ?", quantity)).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// 扣除积分，积分不足时返回 po.ErrPointsInsufficient
+	order := &po.RedeemOrder{
+		OrderID:  uuid.NewString(),
+		UserID:   userID,
+		ItemID:   item.ID,
+		ItemName: item.Name,
+		Quantity: quantity,
+		Points:   item.Price * quantity,
+	}
+	record := &po.PointRecord{
+		UserID: userID,
+		Points: -order.Points,
+		Reason: po.ReasonRedeem,
+		RefID:  order.OrderID,
+	}
+	if err := applyPointRecord(tx, record); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// 创建订单
+	if err := tx.Create(order).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := commitPointRecords(tx, record); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// ListRedeemOrders 按 ID 倒序查询兑换订单
+func (r *ShopRepositoryImpl) ListRedeemOrders(ctx context.Context, filter po.RedeemOrderFilter) ([]*po.RedeemOrder, error) {
+	query := r.db.WithContext(ctx).Model(&po.RedeemOrder{})
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.ItemID > 0 {
+		query = query.Where("item_id = ?", filter.ItemID)
+	}
+	if filter.BeforeID > 0 {
+		query = query.Where("id < ?", filter.BeforeID)
+	}
+
+	var orders []*po.RedeemOrder
+	if err := query.Order("id DESC").Limit(filter.Limit).Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
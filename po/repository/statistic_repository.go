@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"github.com/trancecho/mundo-points-system/po"
 	"gorm.io/gorm"
 	"time"
@@ -61,7 +62,17 @@ func (r *StatisticsRepositoryImpl) GetAveragePoints(ctx context.Context) (float3
 	return result.AvgPoints, nil
 }
 
-// GetMonthlyPointsUsed 获取本月使用的积分
+// nonSpendingReasons 不计入使用积分的支出记录原因
+// 转出只是用户间转移积分；取消点赞、过期、管理员调整和冲正是收回或作废积分，不是用户使用的积分
+var nonSpendingReasons = []string{
+	po.ReasonTransferOut,
+	po.ReasonUnliked,
+	po.ReasonExpired,
+	po.ReasonAdminAdjust,
+	po.ReasonReversal,
+}
+
+// GetMonthlyPointsUsed 获取本月使用的积分，即兑换等消费扣除的积分，被冲正的支出不计入
 func (r *StatisticsRepositoryImpl) GetMonthlyPointsUsed(ctx context.Context) (int64, error) {
 	var result struct {
 		TotalPoints int64
@@ -73,12 +84,14 @@ func (r *StatisticsRepositoryImpl) GetMonthlyPointsUsed(ctx context.Context) (in
 	nextMonth := now.AddDate(0, 1, 0)
 	firstDayNextMonth := time.Date(nextMonth.Year(), nextMonth.Month(), 1, 0, 0, 0, 0, now.Location())
 
-	// 查询当月消耗的积分总量（负值积分记录之和）
+	// 查询当月消耗的积分总量（消费记录的负值积分之和）
 	err := r.db.WithContext(ctx).
 		Model(&po.PointRecord{}).
-		Select("ABS(SUM(points)) as total_points").
+		Select("COALESCE(-SUM(points), 0) as total_points").
 		Where("points < 0 AND created_at >= ? AND created_at < ?", firstDay, firstDayNextMonth).
-		First(&result).Error
+		Where("reason NOT IN ?", nonSpendingReasons).
+		Where("NOT EXISTS (SELECT 1 FROM point_records AS reversal WHERE reversal.reverses_id = point_records.id)").
+		Take(&result).Error
 
 	if err != nil {
 		return 0, err
@@ -86,3 +99,135 @@ func (r *StatisticsRepositoryImpl) GetMonthlyPointsUsed(ctx context.Context) (in
 
 	return result.TotalPoints, nil
 }
+
+// leaderboardColumns 总榜指标对应 user_infos 的列
+var leaderboardColumns = map[po.LeaderboardMetric]string{
+	po.LeaderboardPoints:            "points",
+	po.LeaderboardExperience:        "experience",
+	po.LeaderboardActivityScore:     "activity_score",
+	po.LeaderboardContinuousSignDay: "continuous_sign_day",
+}
+
+// leaderboardRow 排行榜查询结果
+type leaderboardRow struct {
+	UserID string
+	Value  int64
+}
+
+// nonEarningReasons 不计入周期榜的积分记录原因
+// 转账只是用户间转移积分；新用户奖励、管理员调整、过期、兑换和冲正都不是这段时间内挣到的积分
+var nonEarningReasons = []string{
+	po.ReasonTransferOut,
+	po.ReasonTransferIn,
+	po.ReasonWelcome,
+	po.ReasonAdminAdjust,
+	po.ReasonExpired,
+	po.ReasonRedeem,
+	po.ReasonReversal,
+}
+
+// leaderboardQuery 返回按 value 统计的子查询，列为 user_id 和 value
+// 总榜直接读取用户表，连续签到榜只包含没有断签的用户；周期榜按挣得积分的收入记录求和，已被冲正的记录不计入
+func (r *StatisticsRepositoryImpl) leaderboardQuery(ctx context.Context, query po.LeaderboardQuery) (*gorm.DB, error) {
+	if query.Since.IsZero() {
+		column, ok := leaderboardColumns[query.Metric]
+		if !ok {
+			return nil, po.ErrUnsupportedLeaderboard
+		}
+		board := r.db.WithContext(ctx).
+			Model(&po.UserInfo{}).
+			Select("user_id, " + column + " AS value")
+		// 连续签到天数只在下次签到时重置，昨天和今天都没有签到的用户已经断签，不上榜
+		if query.Metric == po.LeaderboardContinuousSignDay {
+			now := time.Now()
+			yesterday := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, now.Location())
+			board = board.Where("last_sign_date >= ?", yesterday)
+		}
+		return board, nil
+	}
+
+	var column string
+	switch query.Metric {
+	case po.LeaderboardPoints:
+		column = "points"
+	case po.LeaderboardExperience:
+		column = "experience"
+	default:
+		return nil, po.ErrUnsupportedLeaderboard
+	}
+	return r.db.WithContext(ctx).
+		Model(&po.PointRecord{}).
+		Select("user_id, SUM("+column+") AS value").
+		Where("created_at >= ?", query.Since).
+		Where("reason NOT IN ?", nonEarningReasons).
+		// 只统计收入，调用方写入的消费记录不减少挣得的积分；取消点赞收回的积分要抵扣被点赞时获得的积分
+		Where("("+column+" > 0 OR reason = ?)", po.ReasonUnliked).
+		Where("NOT EXISTS (SELECT 1 FROM point_records AS reversal WHERE reversal.reverses_id = point_records.id)").
+		Group("user_id").
+		Having("SUM(" + column + ") > 0"), nil
+}
+
+// GetLeaderboard 获取排行榜，数值相同时按用户ID排序
+func (r *StatisticsRepositoryImpl) GetLeaderboard(ctx context.Context, query po.LeaderboardQuery) ([]*po.LeaderboardEntry, error) {
+	sub, err := r.leaderboardQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []leaderboardRow
+	err = r.db.WithContext(ctx).
+		Table("(?) AS board", sub).
+		Order("value DESC, user_id ASC").
+		Limit(query.Limit).
+		Offset(query.Offset).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*po.LeaderboardEntry, 0, len(rows))
+	for i, row := range rows {
+		entries = append(entries, &po.LeaderboardEntry{
+			Rank:   int64(query.Offset + i + 1),
+			UserID: row.UserID,
+			Value:  row.Value,
+		})
+	}
+	return entries, nil
+}
+
+// GetLeaderboardRank 获取指定用户在排行榜中的名次，不在榜上时返回 nil
+func (r *StatisticsRepositoryImpl) GetLeaderboardRank(ctx context.Context, query po.LeaderboardQuery, userID string) (*po.LeaderboardEntry, error) {
+	sub, err := r.leaderboardQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var row leaderboardRow
+	err = r.db.WithContext(ctx).
+		Table("(?) AS board", sub).
+		Where("user_id = ?", userID).
+		Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 排在前面的用户数加一即为名次，排序规则与 GetLeaderboard 一致
+	var ahead int64
+	err = r.db.WithContext(ctx).
+		Table("(?) AS board", sub).
+		Where("value > ? OR (value = ? AND user_id < ?)", row.Value, row.Value, row.UserID).
+		Count(&ahead).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &po.LeaderboardEntry{
+		Rank:   ahead + 1,
+		UserID: row.UserID,
+		Value:  row.Value,
+	}, nil
+}
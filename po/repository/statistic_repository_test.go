@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/trancecho/mundo-points-system/pkg/testdb"
+	"github.com/trancecho/mundo-points-system/po"
+)
+
+func TestContinuousSignBoardSkipsBrokenStreaks(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewStatisticsRepository(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	yesterday := time.Date(now.Year(), now.Month(), now.Day()-1, 8, 0, 0, 0, now.Location())
+	for _, user := range []*po.UserInfo{
+		{UserID: 8001, ContinuousSignDay: 3, LastSignDate: now},
+		{UserID: 8002, ContinuousSignDay: 5, LastSignDate: yesterday},
+		// 几个月前断签，连续签到天数要到下次签到才会重置
+		{UserID: 8003, ContinuousSignDay: 100, LastSignDate: now.AddDate(0, -3, 0)},
+	} {
+		user.Level = 1
+		if err := db.Create(user).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	query := po.LeaderboardQuery{Metric: po.LeaderboardContinuousSignDay, Limit: 10}
+	entries, err := repo.GetLeaderboard(ctx, query)
+	if err != nil {
+		t.Fatalf("GetLeaderboard: %v", err)
+	}
+	if len(entries) != 2 || entries[0].UserID != "8002" || entries[1].UserID != "8001" {
+		t.Fatalf("连续签到榜 = %+v, want 8002、8001", entries)
+	}
+	rank, err := repo.GetLeaderboardRank(ctx, query, "8003")
+	if err != nil || rank != nil {
+		t.Fatalf("断签用户名次 = %+v, %v, want nil", rank, err)
+	}
+}
+
+func TestPeriodicBoardCountsEarningsOnly(t *testing.T) {
+	db := testdb.Open(t)
+	points := NewPointRepository(db)
+	repo := NewStatisticsRepository(db)
+	ctx := context.Background()
+	userA := createUser(t, db, 8101, 0)
+	userB := createUser(t, db, 8102, 0)
+	likerID := createUser(t, db, 8103, 0)
+
+	// A 挣了 50 又消费了 40，消费不减少挣得的积分
+	addPoints(t, points, userA, 50)
+	addPoints(t, points, userA, -40)
+	// B 被点赞两次，其中一次被取消
+	for _, post := range []string{"p1", "p2"} {
+		if err := points.RecordLike(ctx, likerID, post, userB, 20, 1, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := points.UnrecordLike(ctx, likerID, "p2"); err != nil {
+		t.Fatal(err)
+	}
+
+	query := po.LeaderboardQuery{Metric: po.LeaderboardPoints, Since: time.Now().Add(-time.Hour), Limit: 10}
+	entries, err := repo.GetLeaderboard(ctx, query)
+	if err != nil {
+		t.Fatalf("GetLeaderboard: %v", err)
+	}
+	if len(entries) != 2 || entries[0].UserID != userA || entries[0].Value != 50 ||
+		entries[1].UserID != userB || entries[1].Value != 20 {
+		t.Fatalf("周期榜 = %+v, want A 50、B 20", entries)
+	}
+}
+
+func TestUnknownLeaderboardMetric(t *testing.T) {
+	repo := NewStatisticsRepository(testdb.Open(t))
+	for _, query := range []po.LeaderboardQuery{
+		{Metric: po.LeaderboardMetric(99)},
+		{Metric: po.LeaderboardActivityScore, Since: time.Now()},
+	} {
+		if _, err := repo.GetLeaderboard(context.Background(), query); !errors.Is(err, po.ErrUnsupportedLeaderboard) {
+			t.Fatalf("指标 %d 返回 %v, want ErrUnsupportedLeaderboard", query.Metric, err)
+		}
+	}
+}
+
+func TestMonthlyPointsUsedCountsSpendingOnly(t *testing.T) {
+	db := testdb.Open(t)
+	points := NewPointRepository(db)
+	repo := NewStatisticsRepository(db)
+	ctx := context.Background()
+	userA := createUser(t, db, 8201, 1000)
+	userB := createUser(t, db, 8202, 0)
+
+	addPoints(t, points, userA, -30)
+	refunded := addPoints(t, points, userA, -50)
+	if _, err := points.ReversePointRecord(ctx, refunded.ID, "9"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := points.TransferPoints(ctx, userA, userB, 100, 0); err != nil {
+		t.Fatal(err)
+	}
+	audit := &po.AdminAuditRecord{OperatorID: "9", UserID: userA, Points: -200, Reason: "扣回", Ticket: "T-1"}
+	if err := points.AdminAdjustPoints(ctx, audit); err != nil {
+		t.Fatal(err)
+	}
+
+	used, err := repo.GetMonthlyPointsUsed(ctx)
+	if err != nil {
+		t.Fatalf("GetMonthlyPointsUsed: %v", err)
+	}
+	if used != 30 {
+		t.Fatalf("本月使用积分 = %d, want 30", used)
+	}
+}
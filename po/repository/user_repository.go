@@ -3,31 +3,67 @@ package repository
 import (
 	"context"
 	"errors"
+	"strconv"
 	"time"
 
-	"github.com/trancecho/mundo-points-system/pkg/utils"
+	"github.com/trancecho/mundo-points-system/pkg/metrics"
 	"github.com/trancecho/mundo-points-system/po"
-	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepositoryImpl struct {
-	db *gorm.DB
+	db     *gorm.DB
+	levels po.LevelProvider
 }
 
 // NewUserRepository 创建用户仓库实例
-func NewUserRepository(db *gorm.DB) *UserRepositoryImpl {
+func NewUserRepository(db *gorm.DB, levels po.LevelProvider) *UserRepositoryImpl {
 	return &UserRepositoryImpl{
-		db: db,
+		db:     db,
+		levels: levels,
 	}
 }
 
-// GetUserByID 通过用户ID获取用户信息
+// GetUserByID 通过用户ID获取用户信息，用户不存在时返回 po.ErrUserNotFound
 func (r *UserRepositoryImpl) GetUserByID(ctx context.Context, userID string) (*po.UserInfo, error) {
 	var user po.UserInfo
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, po.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetUsersByIDs 批量获取用户信息，不存在的用户不在结果中
+func (r *UserRepositoryImpl) GetUsersByIDs(ctx context.Context, userIDs []string) ([]*po.UserInfo, error) {
+	var users []*po.UserInfo
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+	if err := r.db.WithContext(ctx).Where("user_id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// EnsureUser 用户不存在时创建用户，初始积分记为一条新用户奖励记录
+// 返回的 bool 表示本次是否新建了用户，用户已存在时不做任何变更
+func (r *UserRepositoryImpl) EnsureUser(ctx context.Context, userID int64, username string, initialPoints int64) (*po.UserInfo, bool, error) {
+	user, err := r.GetUserByID(ctx, strconv.FormatInt(userID, 10))
+	if err == nil {
+		return user, false, nil
+	}
+	if !errors.Is(err, po.ErrUserNotFound) {
+		return nil, false, err
+	}
+
 	tx := r.db.WithContext(ctx).Begin()
 	if tx.Error != nil {
-		return nil, tx.Error
+		return nil, false, tx.Error
 	}
 	defer func() {
 		if r := recover(); r != nil {
@@ -35,48 +71,42 @@ func (r *UserRepositoryImpl) GetUserByID(ctx context.Context, userID string) (*p
 		}
 	}()
 
-	// 尝试直接查询用户
-	err := tx.Where("user_id = ?", userID).First(&user).Error
-
-	// 如果用户不存在，则创建新用户
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		// 从上下文获取用户信息
-		userClaims, ok := ctx.Value("claims").(*utils.Claims)
-		if !ok {
-			tx.Rollback()
-			return nil, status.Errorf(400, "failed to get user claims from context")
+	newUser := &po.UserInfo{
+		UserID:       userID,
+		Username:     username,
+		Level:        r.levels.Curve().LevelOf(0),
+		LastSignDate: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), // 使用UNIX纪元时间
+	}
+	if err := tx.Create(newUser).Error; err != nil {
+		tx.Rollback()
+		// 并发创建时以先创建的为准
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			user, err := r.GetUserByID(ctx, strconv.FormatInt(userID, 10))
+			return user, false, err
 		}
+		return nil, false, err
+	}
 
-		// 创建新用户
-		newUser := &po.UserInfo{
-			UserID:            userClaims.UserID,
-			Username:          userClaims.Username,
-			Points:            1200,
-			Experience:        0,
-			Level:             1,
-			IsSigned:          false,
-			ContinuousSignDay: 0,
-			TotalSignDay:      0,
-			LastSignDate:      time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), // 使用UNIX纪元时间
+	var records []*po.PointRecord
+	if initialPoints > 0 {
+		record := &po.PointRecord{
+			UserID: strconv.FormatInt(userID, 10),
+			Points: initialPoints,
+			Reason: po.ReasonWelcome,
 		}
-
-		if err := tx.Create(newUser).Error; err != nil {
+		if err := applyPointRecord(tx, record); err != nil {
 			tx.Rollback()
-			return nil, err
+			return nil, false, err
 		}
-
-		user = *newUser
-	} else if err != nil {
-		tx.Rollback()
-		return nil, err
+		newUser.Points = initialPoints
+		records = append(records, record)
 	}
 
-	// 提交事务
-	if err := tx.Commit().Error; err != nil {
-		return nil, err
+	if err := commitPointRecords(tx, records...); err != nil {
+		return nil, false, err
 	}
 
-	return &user, nil
+	return newUser, true, nil
 }
 
 // UpdateSignStatus 更新用户签到状态
@@ -104,70 +134,61 @@ func (r *UserRepositoryImpl) UpdateSignStatus(ctx context.Context, userID string
 
 	if result.RowsAffected == 0 {
 		tx.Rollback()
-		return errors.New("用户不存在")
+		return po.ErrUserNotFound
 	}
 
 	return tx.Commit().Error
 }
 
-// UpdateLevelByExperience 根据经验值更新用户等级
-func (r *UserRepositoryImpl) UpdateLevelByExperience(ctx context.Context, userID string) error {
+// UpdateLevelByExperience 根据经验值更新用户等级，返回更新前后的等级
+// 等级发生变化时在同一事务内写入等级变更记录
+func (r *UserRepositoryImpl) UpdateLevelByExperience(ctx context.Context, userID string) (int, int, error) {
 	// 开启事务
 	tx := r.db.WithContext(ctx).Begin()
 	if tx.Error != nil {
-		return tx.Error
+		return 0, 0, tx.Error
 	}
 
-	// 获取用户当前经验值
+	// 获取用户当前经验值，加锁避免并发更新时重复记录同一次升级
 	var user po.UserInfo
-	result := tx.Where("user_id = ?", userID).First(&user)
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", userID).First(&user)
 	if result.Error != nil {
 		tx.Rollback()
-		return result.Error
+		return 0, 0, result.Error
 	}
 
 	// 根据经验值计算新等级
-	newLevel := calculateLevelByExperience(user.Experience)
+	oldLevel := user.Level
+	newLevel := r.levels.Curve().LevelOf(user.Experience)
 
-	// 如果等级有变化，则更新
-	if int(newLevel) != user.Level {
+	// 如果等级有变化，则更新并记录
+	if newLevel != oldLevel {
 		result = tx.Model(&po.UserInfo{}).
 			Where("user_id = ?", userID).
 			Update("level", newLevel)
 
 		if result.Error != nil {
 			tx.Rollback()
-			return result.Error
+			return 0, 0, result.Error
 		}
-	}
 
-	return tx.Commit().Error
-}
+		changeRecord := &po.LevelChangeRecord{
+			UserID:     userID,
+			OldLevel:   oldLevel,
+			NewLevel:   newLevel,
+			Experience: user.Experience,
+		}
+		if err := tx.Create(changeRecord).Error; err != nil {
+			tx.Rollback()
+			return 0, 0, err
+		}
+	}
 
-// calculateLevelByExperience 根据经验值计算等级
-func calculateLevelByExperience(experience int64) int32 {
-	// 简单的等级计算规则，可以根据业务需求调整
-	if experience < 100 {
-		return 1
-	} else if experience < 500 {
-		return 2
-	} else if experience < 1000 {
-		return 3
-	} else if experience < 2000 {
-		return 4
-	} else if experience < 5000 {
-		return 5
-	} else if experience < 10000 {
-		return 6
-	} else if experience < 18000 {
-		return 7
-	} else if experience < 30000 {
-		return 8
-	} else if experience < 50000 {
-		return 9
-	} else {
-		return 10
+	if err := tx.Commit().Error; err != nil {
+		return 0, 0, err
 	}
+	metrics.ObserveLevelChange(oldLevel, newLevel)
+	return oldLevel, newLevel, nil
 }
 
 func (r *UserRepositoryImpl) UpdateActivityScore(ctx context.Context, userID string, deltaScore int64) error {
@@ -175,3 +196,26 @@ func (r *UserRepositoryImpl) UpdateActivityScore(ctx context.Context, userID str
 		Where("user_id = ?", userID).
 		Update("activity_score", gorm.Expr("activity_score + ?", deltaScore)).Error
 }
+
+// RecalculateLevels 按当前等级曲线重新计算所有用户的等级，返回等级发生变化的用户数
+// 每个等级区间执行一条 UPDATE，不需要把用户逐个读出来；曲线调整引起的等级变化不写等级变更记录
+func (r *UserRepositoryImpl) RecalculateLevels(ctx context.Context) (int64, error) {
+	curve := r.levels.Curve()
+	var updated int64
+	for i, level := range curve {
+		query := r.db.WithContext(ctx).Model(&po.UserInfo{}).Where("level <> ?", level.Level)
+		// 最低等级也包含经验为负数的用户
+		if i > 0 {
+			query = query.Where("experience >= ?", level.MinExperience)
+		}
+		if i+1 < len(curve) {
+			query = query.Where("experience < ?", curve[i+1].MinExperience)
+		}
+		result := query.Update("level", level.Level)
+		if result.Error != nil {
+			return updated, result.Error
+		}
+		updated += result.RowsAffected
+	}
+	return updated, nil
+}
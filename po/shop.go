@@ -0,0 +1,52 @@
+package po
+
+import "time"
+
+// RewardItem 积分商城商品
+type RewardItem struct {
+	BaseModel
+	Name         string `gorm:"column:name;size:128;not null"`
+	Description  string `gorm:"column:description;size:1024"`
+	Price        int64  `gorm:"column:price;not null"`                    // 单价（积分）
+	Stock        int64  `gorm:"column:stock;not null"`                    // 剩余库存
+	PerUserLimit int64  `gorm:"column:per_user_limit;not null;default:0"` // 每个用户最多兑换的数量，0 表示不限
+	// 上架时间窗口，为空表示不限
+	StartAt *time.Time `gorm:"column:start_at"`
+	EndAt   *time.Time `gorm:"column:end_at"`
+	Active  bool       `gorm:"column:active;not null"` // 是否上架
+}
+
+// Available 判断商品在指定时间是否可以兑换
+func (i *RewardItem) Available(now time.Time) bool {
+	if !i.Active {
+		return false
+	}
+	if i.StartAt != nil && now.Before(*i.StartAt) {
+		return false
+	}
+	if i.EndAt != nil && !now.Before(*i.EndAt) {
+		return false
+	}
+	return true
+}
+
+// RedeemOrder 兑换订单，对应一条扣除积分的记录
+type RedeemOrder struct {
+	BaseModel
+	OrderID  string `gorm:"column:order_id;size:64;not null;uniqueIndex"`
+	UserID   string `gorm:"column:user_id;not null;index"`
+	ItemID   int64  `gorm:"column:item_id;not null;index"`
+	ItemName string `gorm:"column:item_name;size:128;not null"`
+	Quantity int64  `gorm:"column:quantity;not null"`
+	Points   int64  `gorm:"column:points;not null"` // 花费的积分
+	// 冲正扣除积分的记录时取消订单并恢复库存，为空表示未取消
+	CancelledAt *time.Time `gorm:"column:cancelled_at"`
+}
+
+// RedeemOrderFilter 兑换订单查询条件
+type RedeemOrderFilter struct {
+	UserID   string // 为空表示所有用户
+	ItemID   int64  // 为 0 表示所有商品
+	BeforeID int64  // 游标，只返回 ID 小于该值的订单，0 表示从最新一条开始
+	Limit    int
+}
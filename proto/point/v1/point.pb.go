@@ -21,6 +21,159 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// 积分变动方向
+type PointDirection int32
+
+const (
+	PointDirection_DIRECTION_ALL   PointDirection = 0 // 全部
+	PointDirection_DIRECTION_EARN  PointDirection = 1 // 收入
+	PointDirection_DIRECTION_SPEND PointDirection = 2 // 支出
+)
+
+// Enum value maps for PointDirection.
+var (
+	PointDirection_name = map[int32]string{
+		0: "DIRECTION_ALL",
+		1: "DIRECTION_EARN",
+		2: "DIRECTION_SPEND",
+	}
+	PointDirection_value = map[string]int32{
+		"DIRECTION_ALL":   0,
+		"DIRECTION_EARN":  1,
+		"DIRECTION_SPEND": 2,
+	}
+)
+
+func (x PointDirection) Enum() *PointDirection {
+	p := new(PointDirection)
+	*p = x
+	return p
+}
+
+func (x PointDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PointDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_point_v1_point_proto_enumTypes[0].Descriptor()
+}
+
+func (PointDirection) Type() protoreflect.EnumType {
+	return &file_point_v1_point_proto_enumTypes[0]
+}
+
+func (x PointDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PointDirection.Descriptor instead.
+func (PointDirection) EnumDescriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{0}
+}
+
+// 排行榜指标
+type LeaderboardMetric int32
+
+const (
+	LeaderboardMetric_METRIC_POINTS               LeaderboardMetric = 0 // 积分
+	LeaderboardMetric_METRIC_EXPERIENCE           LeaderboardMetric = 1 // 经验
+	LeaderboardMetric_METRIC_ACTIVITY_SCORE       LeaderboardMetric = 2 // 活跃度，只支持总榜
+	LeaderboardMetric_METRIC_CONTINUOUS_SIGN_DAYS LeaderboardMetric = 3 // 连续签到天数，只支持总榜，昨天和今天都没有签到的用户不上榜
+)
+
+// Enum value maps for LeaderboardMetric.
+var (
+	LeaderboardMetric_name = map[int32]string{
+		0: "METRIC_POINTS",
+		1: "METRIC_EXPERIENCE",
+		2: "METRIC_ACTIVITY_SCORE",
+		3: "METRIC_CONTINUOUS_SIGN_DAYS",
+	}
+	LeaderboardMetric_value = map[string]int32{
+		"METRIC_POINTS":               0,
+		"METRIC_EXPERIENCE":           1,
+		"METRIC_ACTIVITY_SCORE":       2,
+		"METRIC_CONTINUOUS_SIGN_DAYS": 3,
+	}
+)
+
+func (x LeaderboardMetric) Enum() *LeaderboardMetric {
+	p := new(LeaderboardMetric)
+	*p = x
+	return p
+}
+
+func (x LeaderboardMetric) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LeaderboardMetric) Descriptor() protoreflect.EnumDescriptor {
+	return file_point_v1_point_proto_enumTypes[1].Descriptor()
+}
+
+func (LeaderboardMetric) Type() protoreflect.EnumType {
+	return &file_point_v1_point_proto_enumTypes[1]
+}
+
+func (x LeaderboardMetric) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LeaderboardMetric.Descriptor instead.
+func (LeaderboardMetric) EnumDescriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{1}
+}
+
+// 排行榜周期
+type LeaderboardPeriod int32
+
+const (
+	LeaderboardPeriod_PERIOD_ALL_TIME LeaderboardPeriod = 0 // 总榜，按当前值排名
+	LeaderboardPeriod_PERIOD_WEEK     LeaderboardPeriod = 1 // 本周（周一起），按挣得的积分排名，只计收入记录（减去取消点赞收回的积分），不计转账、新用户奖励、管理员调整和被冲正的记录
+	LeaderboardPeriod_PERIOD_MONTH    LeaderboardPeriod = 2 // 本月，规则同 PERIOD_WEEK
+)
+
+// Enum value maps for LeaderboardPeriod.
+var (
+	LeaderboardPeriod_name = map[int32]string{
+		0: "PERIOD_ALL_TIME",
+		1: "PERIOD_WEEK",
+		2: "PERIOD_MONTH",
+	}
+	LeaderboardPeriod_value = map[string]int32{
+		"PERIOD_ALL_TIME": 0,
+		"PERIOD_WEEK":     1,
+		"PERIOD_MONTH":    2,
+	}
+)
+
+func (x LeaderboardPeriod) Enum() *LeaderboardPeriod {
+	p := new(LeaderboardPeriod)
+	*p = x
+	return p
+}
+
+func (x LeaderboardPeriod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LeaderboardPeriod) Descriptor() protoreflect.EnumDescriptor {
+	return file_point_v1_point_proto_enumTypes[2].Descriptor()
+}
+
+func (LeaderboardPeriod) Type() protoreflect.EnumType {
+	return &file_point_v1_point_proto_enumTypes[2]
+}
+
+func (x LeaderboardPeriod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LeaderboardPeriod.Descriptor instead.
+func (LeaderboardPeriod) EnumDescriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{2}
+}
+
 // 错误码枚举
 type ErrorCode int32
 
@@ -30,6 +183,8 @@ const (
 	ErrorCode_OPERATION_FAILED    ErrorCode = 2
 	ErrorCode_INVALID_REQUEST     ErrorCode = 3
 	ErrorCode_NONE_ERROR          ErrorCode = 4 // 无错误
+	ErrorCode_LIMIT_EXCEEDED      ErrorCode = 5 // 超出限额
+	ErrorCode_OUT_OF_STOCK        ErrorCode = 6 // 库存不足
 )
 
 // Enum value maps for ErrorCode.
@@ -40,6 +195,8 @@ var (
 		2: "OPERATION_FAILED",
 		3: "INVALID_REQUEST",
 		4: "NONE_ERROR",
+		5: "LIMIT_EXCEEDED",
+		6: "OUT_OF_STOCK",
 	}
 	ErrorCode_value = map[string]int32{
 		"UNKNOWN_ERROR":       0,
@@ -47,6 +204,8 @@ var (
 		"OPERATION_FAILED":    2,
 		"INVALID_REQUEST":     3,
 		"NONE_ERROR":          4,
+		"LIMIT_EXCEEDED":      5,
+		"OUT_OF_STOCK":        6,
 	}
 )
 
@@ -61,11 +220,11 @@ func (x ErrorCode) String() string {
 }
 
 func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
-	return file_point_v1_point_proto_enumTypes[0].Descriptor()
+	return file_point_v1_point_proto_enumTypes[3].Descriptor()
 }
 
 func (ErrorCode) Type() protoreflect.EnumType {
-	return &file_point_v1_point_proto_enumTypes[0]
+	return &file_point_v1_point_proto_enumTypes[3]
 }
 
 func (x ErrorCode) Number() protoreflect.EnumNumber {
@@ -74,7 +233,7 @@ func (x ErrorCode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ErrorCode.Descriptor instead.
 func (ErrorCode) EnumDescriptor() ([]byte, []int) {
-	return file_point_v1_point_proto_rawDescGZIP(), []int{0}
+	return file_point_v1_point_proto_rawDescGZIP(), []int{3}
 }
 
 // 用户信息
@@ -89,6 +248,8 @@ type UserInfo struct {
 	ContinuousSignDays int32                  `protobuf:"varint,7,opt,name=continuous_sign_days,json=continuousSignDays,proto3" json:"continuous_sign_days,omitempty"` // 连续签到天数
 	TotalSignDays      int32                  `protobuf:"varint,8,opt,name=total_sign_days,json=totalSignDays,proto3" json:"total_sign_days,omitempty"`                // 总签到天数
 	ActivityScore      int64                  `protobuf:"varint,9,opt,name=activity_score,json=activityScore,proto3" json:"activity_score,omitempty"`                  // 当前活跃度
+	ExpiringPoints     int64                  `protobuf:"varint,10,opt,name=expiring_points,json=expiringPoints,proto3" json:"expiring_points,omitempty"`              // 即将过期的积分，只有 GetUserInfo 返回
+	NextExpireTime     int64                  `protobuf:"varint,11,opt,name=next_expire_time,json=nextExpireTime,proto3" json:"next_expire_time,omitempty"`            // 即将过期积分中最早的过期时间（Unix 秒），没有时为 0
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
@@ -186,6 +347,20 @@ func (x *UserInfo) GetActivityScore() int64 {
 	return 0
 }
 
+func (x *UserInfo) GetExpiringPoints() int64 {
+	if x != nil {
+		return x.ExpiringPoints
+	}
+	return 0
+}
+
+func (x *UserInfo) GetNextExpireTime() int64 {
+	if x != nil {
+		return x.NextExpireTime
+	}
+	return 0
+}
+
 // 积分/经验变更请求
 type UpdatePointsRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -193,6 +368,7 @@ type UpdatePointsRequest struct {
 	DeltaPoints     int64                  `protobuf:"varint,2,opt,name=delta_points,json=deltaPoints,proto3" json:"delta_points,omitempty"`             // 积分变化量（正加负扣）
 	DeltaExperience int64                  `protobuf:"varint,3,opt,name=delta_experience,json=deltaExperience,proto3" json:"delta_experience,omitempty"` // 经验变化量
 	Reason          string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`                                           // 变更原因（如"签到"、"发帖"）
+	IdempotencyKey  string                 `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`     // 幂等键（可选），相同键的重试请求只会生效一次并返回首次的结果，内容不同时返回 INVALID_REQUEST
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -255,30 +431,42 @@ func (x *UpdatePointsRequest) GetReason() string {
 	return ""
 }
 
-// 通用响应
-type CommonResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	ErrorCode     ErrorCode              `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=mundo.system.point.ErrorCode" json:"error_code,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *UpdatePointsRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
 }
 
-func (x *CommonResponse) Reset() {
-	*x = CommonResponse{}
+// 积分/经验变更响应
+// 前三个字段与 CommonResponse 的编号和类型保持一致，旧客户端按 CommonResponse 解码仍然可用
+type UpdatePointsResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Success           bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message           string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ErrorCode         ErrorCode              `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=mundo.system.point.ErrorCode" json:"error_code,omitempty"`
+	LevelChange       *LevelChange           `protobuf:"bytes,4,opt,name=level_change,json=levelChange,proto3" json:"level_change,omitempty"`                    // 等级变化，等级没有变化时为空
+	GrantedPoints     int64                  `protobuf:"varint,5,opt,name=granted_points,json=grantedPoints,proto3" json:"granted_points,omitempty"`             // 实际变更的积分，达到每日获取上限（point_quota，只限制积分）时可能小于请求的积分
+	GrantedExperience int64                  `protobuf:"varint,6,opt,name=granted_experience,json=grantedExperience,proto3" json:"granted_experience,omitempty"` // 实际变更的经验，不受每日获取上限限制
+	RecordId          int64                  `protobuf:"varint,7,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`                            // 积分记录ID，重放的请求返回首次写入的记录
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UpdatePointsResponse) Reset() {
+	*x = UpdatePointsResponse{}
 	mi := &file_point_v1_point_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CommonResponse) String() string {
+func (x *UpdatePointsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CommonResponse) ProtoMessage() {}
+func (*UpdatePointsResponse) ProtoMessage() {}
 
-func (x *CommonResponse) ProtoReflect() protoreflect.Message {
+func (x *UpdatePointsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_point_v1_point_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -290,56 +478,83 @@ func (x *CommonResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CommonResponse.ProtoReflect.Descriptor instead.
-func (*CommonResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdatePointsResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePointsResponse) Descriptor() ([]byte, []int) {
 	return file_point_v1_point_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *CommonResponse) GetSuccess() bool {
+func (x *UpdatePointsResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *CommonResponse) GetMessage() string {
+func (x *UpdatePointsResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *CommonResponse) GetErrorCode() ErrorCode {
+func (x *UpdatePointsResponse) GetErrorCode() ErrorCode {
 	if x != nil {
 		return x.ErrorCode
 	}
 	return ErrorCode_UNKNOWN_ERROR
 }
 
-// 点赞请求
-type LikeRequest struct {
+func (x *UpdatePointsResponse) GetLevelChange() *LevelChange {
+	if x != nil {
+		return x.LevelChange
+	}
+	return nil
+}
+
+func (x *UpdatePointsResponse) GetGrantedPoints() int64 {
+	if x != nil {
+		return x.GrantedPoints
+	}
+	return 0
+}
+
+func (x *UpdatePointsResponse) GetGrantedExperience() int64 {
+	if x != nil {
+		return x.GrantedExperience
+	}
+	return 0
+}
+
+func (x *UpdatePointsResponse) GetRecordId() int64 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+// 等级变化
+type LevelChange struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	PostId        string                 `protobuf:"bytes,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
-	TargetUserId  string                 `protobuf:"bytes,3,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"` // 被点赞的用户ID
+	OldLevel      int32                  `protobuf:"varint,1,opt,name=old_level,json=oldLevel,proto3" json:"old_level,omitempty"`
+	NewLevel      int32                  `protobuf:"varint,2,opt,name=new_level,json=newLevel,proto3" json:"new_level,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LikeRequest) Reset() {
-	*x = LikeRequest{}
+func (x *LevelChange) Reset() {
+	*x = LevelChange{}
 	mi := &file_point_v1_point_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LikeRequest) String() string {
+func (x *LevelChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LikeRequest) ProtoMessage() {}
+func (*LevelChange) ProtoMessage() {}
 
-func (x *LikeRequest) ProtoReflect() protoreflect.Message {
+func (x *LevelChange) ProtoReflect() protoreflect.Message {
 	mi := &file_point_v1_point_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -351,54 +566,49 @@ func (x *LikeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LikeRequest.ProtoReflect.Descriptor instead.
-func (*LikeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LevelChange.ProtoReflect.Descriptor instead.
+func (*LevelChange) Descriptor() ([]byte, []int) {
 	return file_point_v1_point_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *LikeRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *LikeRequest) GetPostId() string {
+func (x *LevelChange) GetOldLevel() int32 {
 	if x != nil {
-		return x.PostId
+		return x.OldLevel
 	}
-	return ""
+	return 0
 }
 
-func (x *LikeRequest) GetTargetUserId() string {
+func (x *LevelChange) GetNewLevel() int32 {
 	if x != nil {
-		return x.TargetUserId
+		return x.NewLevel
 	}
-	return ""
+	return 0
 }
 
-// 获取用户信息请求
-type GetUserInfoRequest struct {
+// 通用响应
+type CommonResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ErrorCode     ErrorCode              `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=mundo.system.point.ErrorCode" json:"error_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserInfoRequest) Reset() {
-	*x = GetUserInfoRequest{}
+func (x *CommonResponse) Reset() {
+	*x = CommonResponse{}
 	mi := &file_point_v1_point_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserInfoRequest) String() string {
+func (x *CommonResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserInfoRequest) ProtoMessage() {}
+func (*CommonResponse) ProtoMessage() {}
 
-func (x *GetUserInfoRequest) ProtoReflect() protoreflect.Message {
+func (x *CommonResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_point_v1_point_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -410,40 +620,55 @@ func (x *GetUserInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserInfoRequest.ProtoReflect.Descriptor instead.
-func (*GetUserInfoRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CommonResponse.ProtoReflect.Descriptor instead.
+func (*CommonResponse) Descriptor() ([]byte, []int) {
 	return file_point_v1_point_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetUserInfoRequest) GetUserId() string {
+func (x *CommonResponse) GetSuccess() bool {
 	if x != nil {
-		return x.UserId
+		return x.Success
+	}
+	return false
+}
+
+func (x *CommonResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-// 签到请求
-type SignRequest struct {
+func (x *CommonResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_UNKNOWN_ERROR
+}
+
+// 积分转账请求，转出方为当前登录用户
+type TransferPointsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ToUserId      string                 `protobuf:"bytes,1,opt,name=to_user_id,json=toUserId,proto3" json:"to_user_id,omitempty"` // 接收方用户ID
+	Points        int64                  `protobuf:"varint,2,opt,name=points,proto3" json:"points,omitempty"`                      // 转账积分，必须大于0
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SignRequest) Reset() {
-	*x = SignRequest{}
+func (x *TransferPointsRequest) Reset() {
+	*x = TransferPointsRequest{}
 	mi := &file_point_v1_point_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SignRequest) String() string {
+func (x *TransferPointsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignRequest) ProtoMessage() {}
+func (*TransferPointsRequest) ProtoMessage() {}
 
-func (x *SignRequest) ProtoReflect() protoreflect.Message {
+func (x *TransferPointsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_point_v1_point_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -455,45 +680,50 @@ func (x *SignRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignRequest.ProtoReflect.Descriptor instead.
-func (*SignRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TransferPointsRequest.ProtoReflect.Descriptor instead.
+func (*TransferPointsRequest) Descriptor() ([]byte, []int) {
 	return file_point_v1_point_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *SignRequest) GetUserId() string {
+func (x *TransferPointsRequest) GetToUserId() string {
 	if x != nil {
-		return x.UserId
+		return x.ToUserId
 	}
 	return ""
 }
 
-// 签到响应
-type SignResponse struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	Success            bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message            string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	ErrorCode          ErrorCode              `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=mundo.system.point.ErrorCode" json:"error_code,omitempty"`
-	Points             int64                  `protobuf:"varint,4,opt,name=points,proto3" json:"points,omitempty"`                                                     // 签到获得的积分
-	Experience         int64                  `protobuf:"varint,5,opt,name=experience,proto3" json:"experience,omitempty"`                                             // 签到获得的经验
-	ContinuousSignDays int32                  `protobuf:"varint,6,opt,name=continuous_sign_days,json=continuousSignDays,proto3" json:"continuous_sign_days,omitempty"` // 连续签到天数
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+func (x *TransferPointsRequest) GetPoints() int64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
 }
 
-func (x *SignResponse) Reset() {
-	*x = SignResponse{}
+// 积分转账响应
+type TransferPointsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ErrorCode     ErrorCode              `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=mundo.system.point.ErrorCode" json:"error_code,omitempty"`
+	TransferId    string                 `protobuf:"bytes,4,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"` // 转账ID，关联转出和转入两条积分记录
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferPointsResponse) Reset() {
+	*x = TransferPointsResponse{}
 	mi := &file_point_v1_point_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SignResponse) String() string {
+func (x *TransferPointsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignResponse) ProtoMessage() {}
+func (*TransferPointsResponse) ProtoMessage() {}
 
-func (x *SignResponse) ProtoReflect() protoreflect.Message {
+func (x *TransferPointsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_point_v1_point_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -505,78 +735,2103 @@ func (x *SignResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignResponse.ProtoReflect.Descriptor instead.
-func (*SignResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use TransferPointsResponse.ProtoReflect.Descriptor instead.
+func (*TransferPointsResponse) Descriptor() ([]byte, []int) {
 	return file_point_v1_point_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *SignResponse) GetSuccess() bool {
+func (x *TransferPointsResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *SignResponse) GetMessage() string {
+func (x *TransferPointsResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *SignResponse) GetErrorCode() ErrorCode {
+func (x *TransferPointsResponse) GetErrorCode() ErrorCode {
 	if x != nil {
 		return x.ErrorCode
 	}
 	return ErrorCode_UNKNOWN_ERROR
 }
 
-func (x *SignResponse) GetPoints() int64 {
+func (x *TransferPointsResponse) GetTransferId() string {
 	if x != nil {
-		return x.Points
+		return x.TransferId
 	}
-	return 0
+	return ""
+}
+
+// 点赞请求
+type LikeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PostId        string                 `protobuf:"bytes,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	TargetUserId  string                 `protobuf:"bytes,3,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"` // 被点赞的用户ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikeRequest) Reset() {
+	*x = LikeRequest{}
+	mi := &file_point_v1_point_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikeRequest) ProtoMessage() {}
+
+func (x *LikeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikeRequest.ProtoReflect.Descriptor instead.
+func (*LikeRequest) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LikeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LikeRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *LikeRequest) GetTargetUserId() string {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return ""
+}
+
+// 获取用户信息请求
+type GetUserInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserInfoRequest) Reset() {
+	*x = GetUserInfoRequest{}
+	mi := &file_point_v1_point_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserInfoRequest) ProtoMessage() {}
+
+func (x *GetUserInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetUserInfoRequest) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetUserInfoRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// 批量获取用户信息请求
+type BatchGetUserInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"` // 用户ID列表，最多 100 个，重复的ID只查询一次；ID 必须是不带前导零的正整数，否则返回 INVALID_ARGUMENT
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetUserInfoRequest) Reset() {
+	*x = BatchGetUserInfoRequest{}
+	mi := &file_point_v1_point_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetUserInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetUserInfoRequest) ProtoMessage() {}
+
+func (x *BatchGetUserInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetUserInfoRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetUserInfoRequest) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BatchGetUserInfoRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+// 批量获取用户信息响应
+type BatchGetUserInfoResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Users          map[string]*UserInfo   `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 按用户ID索引的用户信息
+	MissingUserIds []string               `protobuf:"bytes,2,rep,name=missing_user_ids,json=missingUserIds,proto3" json:"missing_user_ids,omitempty"`                                 // 不存在的用户ID
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BatchGetUserInfoResponse) Reset() {
+	*x = BatchGetUserInfoResponse{}
+	mi := &file_point_v1_point_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetUserInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetUserInfoResponse) ProtoMessage() {}
+
+func (x *BatchGetUserInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetUserInfoResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetUserInfoResponse) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BatchGetUserInfoResponse) GetUsers() map[string]*UserInfo {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *BatchGetUserInfoResponse) GetMissingUserIds() []string {
+	if x != nil {
+		return x.MissingUserIds
+	}
+	return nil
+}
+
+// 签到请求
+type SignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 可以为空，不为空时必须是当前用户
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignRequest) Reset() {
+	*x = SignRequest{}
+	mi := &file_point_v1_point_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignRequest) ProtoMessage() {}
+
+func (x *SignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignRequest.ProtoReflect.Descriptor instead.
+func (*SignRequest) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SignRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// 创建用户请求
+type EnsureUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 为空时为当前用户，为其他用户创建需要管理员或服务账号权限
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`           // 为当前用户创建时取登录信息中的用户名
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnsureUserRequest) Reset() {
+	*x = EnsureUserRequest{}
+	mi := &file_point_v1_point_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnsureUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnsureUserRequest) ProtoMessage() {}
+
+func (x *EnsureUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnsureUserRequest.ProtoReflect.Descriptor instead.
+func (*EnsureUserRequest) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *EnsureUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *EnsureUserRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+// 创建用户响应，用户已存在时返回现有信息
+type EnsureUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ErrorCode     ErrorCode              `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=mundo.system.point.ErrorCode" json:"error_code,omitempty"`
+	User          *UserInfo              `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	Created       bool                   `protobuf:"varint,5,opt,name=created,proto3" json:"created,omitempty"` // 本次调用是否新建了用户
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnsureUserResponse) Reset() {
+	*x = EnsureUserResponse{}
+	mi := &file_point_v1_point_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnsureUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnsureUserResponse) ProtoMessage() {}
+
+func (x *EnsureUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnsureUserResponse.ProtoReflect.Descriptor instead.
+func (*EnsureUserResponse) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *EnsureUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *EnsureUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *EnsureUserResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_UNKNOWN_ERROR
+}
+
+func (x *EnsureUserResponse) GetUser() *UserInfo {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *EnsureUserResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+// 签到响应
+// 前三个字段与 CommonResponse 的编号和类型保持一致，旧客户端按 CommonResponse 解码仍然可用
+type SignResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Success            bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message            string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ErrorCode          ErrorCode              `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=mundo.system.point.ErrorCode" json:"error_code,omitempty"`
+	Points             int64                  `protobuf:"varint,4,opt,name=points,proto3" json:"points,omitempty"`                                                     // 签到获得的积分
+	Experience         int64                  `protobuf:"varint,5,opt,name=experience,proto3" json:"experience,omitempty"`                                             // 签到获得的经验
+	ContinuousSignDays int32                  `protobuf:"varint,6,opt,name=continuous_sign_days,json=continuousSignDays,proto3" json:"continuous_sign_days,omitempty"` // 连续签到天数
+	Activity           int64                  `protobuf:"varint,7,opt,name=activity,proto3" json:"activity,omitempty"`                                                 // 签到获得的活跃度
+	Level              int32                  `protobuf:"varint,8,opt,name=level,proto3" json:"level,omitempty"`                                                       // 签到后的等级
+	LevelChange        *LevelChange           `protobuf:"bytes,9,opt,name=level_change,json=levelChange,proto3" json:"level_change,omitempty"`                         // 等级变化，等级没有变化时为空
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SignResponse) Reset() {
+	*x = SignResponse{}
+	mi := &file_point_v1_point_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignResponse) ProtoMessage() {}
+
+func (x *SignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignResponse.ProtoReflect.Descriptor instead.
+func (*SignResponse) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SignResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SignResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SignResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_UNKNOWN_ERROR
+}
+
+func (x *SignResponse) GetPoints() int64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
 }
 
 func (x *SignResponse) GetExperience() int64 {
 	if x != nil {
-		return x.Experience
+		return x.Experience
+	}
+	return 0
+}
+
+func (x *SignResponse) GetContinuousSignDays() int32 {
+	if x != nil {
+		return x.ContinuousSignDays
+	}
+	return 0
+}
+
+func (x *SignResponse) GetActivity() int64 {
+	if x != nil {
+		return x.Activity
+	}
+	return 0
+}
+
+func (x *SignResponse) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *SignResponse) GetLevelChange() *LevelChange {
+	if x != nil {
+		return x.LevelChange
+	}
+	return nil
+}
+
+// 后台统计数据
+type AdminStats struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	LevelDistribution []*LevelDistribution   `protobuf:"bytes,1,rep,name=level_distribution,json=levelDistribution,proto3" json:"level_distribution,omitempty"`
+	AvgPoints         float32                `protobuf:"fixed32,2,opt,name=avg_points,json=avgPoints,proto3" json:"avg_points,omitempty"`
+	MonthlyPointsUsed int64                  `protobuf:"varint,3,opt,name=monthly_points_used,json=monthlyPointsUsed,proto3" json:"monthly_points_used,omitempty"` // 本月兑换等消费使用的积分，不含转出、取消点赞、过期、管理员调整和被冲正的支出
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *AdminStats) Reset() {
+	*x = AdminStats{}
+	mi := &file_point_v1_point_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminStats) ProtoMessage() {}
+
+func (x *AdminStats) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminStats.ProtoReflect.Descriptor instead.
+func (*AdminStats) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AdminStats) GetLevelDistribution() []*LevelDistribution {
+	if x != nil {
+		return x.LevelDistribution
+	}
+	return nil
+}
+
+func (x *AdminStats) GetAvgPoints() float32 {
+	if x != nil {
+		return x.AvgPoints
+	}
+	return 0
+}
+
+func (x *AdminStats) GetMonthlyPointsUsed() int64 {
+	if x != nil {
+		return x.MonthlyPointsUsed
+	}
+	return 0
+}
+
+// 等级分布
+type LevelDistribution struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         int32                  `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+	UserCount     int64                  `protobuf:"varint,2,opt,name=user_count,json=userCount,proto3" json:"user_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LevelDistribution) Reset() {
+	*x = LevelDistribution{}
+	mi := &file_point_v1_point_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LevelDistribution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LevelDistribution) ProtoMessage() {}
+
+func (x *LevelDistribution) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LevelDistribution.ProtoReflect.Descriptor instead.
+func (*LevelDistribution) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *LevelDistribution) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *LevelDistribution) GetUserCount() int64 {
+	if x != nil {
+		return x.UserCount
+	}
+	return 0
+}
+
+// 积分记录
+type PointRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Points        int64                  `protobuf:"varint,3,opt,name=points,proto3" json:"points,omitempty"`                                     // 积分变化量
+	Experience    int64                  `protobuf:"varint,4,opt,name=experience,proto3" json:"experience,omitempty"`                             // 经验变化量
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`                                      // 变更原因
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`              // 变更时间（Unix 秒）
+	TransferId    string                 `protobuf:"bytes,7,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`            // 转账ID，转账产生的记录才有
+	RelatedUserId string                 `protobuf:"bytes,8,opt,name=related_user_id,json=relatedUserId,proto3" json:"related_user_id,omitempty"` // 关联用户，如点赞者
+	RefId         string                 `protobuf:"bytes,9,opt,name=ref_id,json=refId,proto3" json:"ref_id,omitempty"`                           // 关联业务ID，如被点赞的帖子ID
+	ReversesId    int64                  `protobuf:"varint,10,opt,name=reverses_id,json=reversesId,proto3" json:"reverses_id,omitempty"`          // 冲正记录对应的原记录ID，不是冲正记录时为 0
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PointRecord) Reset() {
+	*x = PointRecord{}
+	mi := &file_point_v1_point_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PointRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PointRecord) ProtoMessage() {}
+
+func (x *PointRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PointRecord.ProtoReflect.Descriptor instead.
+func (*PointRecord) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PointRecord) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PointRecord) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PointRecord) GetPoints() int64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *PointRecord) GetExperience() int64 {
+	if x != nil {
+		return x.Experience
+	}
+	return 0
+}
+
+func (x *PointRecord) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PointRecord) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *PointRecord) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *PointRecord) GetRelatedUserId() string {
+	if x != nil {
+		return x.RelatedUserId
+	}
+	return ""
+}
+
+func (x *PointRecord) GetRefId() string {
+	if x != nil {
+		return x.RefId
+	}
+	return ""
+}
+
+func (x *PointRecord) GetReversesId() int64 {
+	if x != nil {
+		return x.ReversesId
+	}
+	return 0
+}
+
+// 冲正积分记录请求
+type ReversePointRecordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecordId      int64                  `protobuf:"varint,1,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"` // 要冲正的积分记录ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReversePointRecordRequest) Reset() {
+	*x = ReversePointRecordRequest{}
+	mi := &file_point_v1_point_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReversePointRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReversePointRecordRequest) ProtoMessage() {}
+
+func (x *ReversePointRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReversePointRecordRequest.ProtoReflect.Descriptor instead.
+func (*ReversePointRecordRequest) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReversePointRecordRequest) GetRecordId() int64 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+// 冲正积分记录响应
+type ReversePointRecordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ErrorCode     ErrorCode              `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=mundo.system.point.ErrorCode" json:"error_code,omitempty"`
+	Record        *PointRecord           `protobuf:"bytes,4,opt,name=record,proto3" json:"record,omitempty"`                              // 新写入的冲正记录
+	LevelChange   *LevelChange           `protobuf:"bytes,5,opt,name=level_change,json=levelChange,proto3" json:"level_change,omitempty"` // 等级变化，等级没有变化时为空
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReversePointRecordResponse) Reset() {
+	*x = ReversePointRecordResponse{}
+	mi := &file_point_v1_point_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReversePointRecordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReversePointRecordResponse) ProtoMessage() {}
+
+func (x *ReversePointRecordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReversePointRecordResponse.ProtoReflect.Descriptor instead.
+func (*ReversePointRecordResponse) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReversePointRecordResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReversePointRecordResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReversePointRecordResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_UNKNOWN_ERROR
+}
+
+func (x *ReversePointRecordResponse) GetRecord() *PointRecord {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *ReversePointRecordResponse) GetLevelChange() *LevelChange {
+	if x != nil {
+		return x.LevelChange
+	}
+	return nil
+}
+
+// 积分记录查询请求
+type ListPointRecordsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                 // 为空时查询当前用户，查询其他用户需要管理员权限
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`                                               // 分页游标，首次查询留空
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`                          // 每页条数，默认 20，最大 100
+	StartTime     int64                  `protobuf:"varint,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`                       // 起始时间（Unix 秒，包含），0 表示不限
+	EndTime       int64                  `protobuf:"varint,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`                             // 结束时间（Unix 秒，不包含），0 表示不限
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`                                               // 按变更原因过滤
+	Direction     PointDirection         `protobuf:"varint,7,opt,name=direction,proto3,enum=mundo.system.point.PointDirection" json:"direction,omitempty"` // 按收入/支出过滤
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPointRecordsRequest) Reset() {
+	*x = ListPointRecordsRequest{}
+	mi := &file_point_v1_point_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPointRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPointRecordsRequest) ProtoMessage() {}
+
+func (x *ListPointRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPointRecordsRequest.ProtoReflect.Descriptor instead.
+func (*ListPointRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListPointRecordsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListPointRecordsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListPointRecordsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPointRecordsRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *ListPointRecordsRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *ListPointRecordsRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ListPointRecordsRequest) GetDirection() PointDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return PointDirection_DIRECTION_ALL
+}
+
+// 积分记录查询响应
+type ListPointRecordsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*PointRecord         `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // 下一页游标，为空表示没有更多数据
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPointRecordsResponse) Reset() {
+	*x = ListPointRecordsResponse{}
+	mi := &file_point_v1_point_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPointRecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPointRecordsResponse) ProtoMessage() {}
+
+func (x *ListPointRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPointRecordsResponse.ProtoReflect.Descriptor instead.
+func (*ListPointRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListPointRecordsResponse) GetRecords() []*PointRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *ListPointRecordsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// 等级定义
+type LevelDefinition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         int32                  `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                         // 等级名称
+	MinExperience int64                  `protobuf:"varint,3,opt,name=min_experience,json=minExperience,proto3" json:"min_experience,omitempty"` // 升到该等级需要的经验值
+	Perks         []string               `protobuf:"bytes,4,rep,name=perks,proto3" json:"perks,omitempty"`                                       // 等级权益
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LevelDefinition) Reset() {
+	*x = LevelDefinition{}
+	mi := &file_point_v1_point_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LevelDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LevelDefinition) ProtoMessage() {}
+
+func (x *LevelDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LevelDefinition.ProtoReflect.Descriptor instead.
+func (*LevelDefinition) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *LevelDefinition) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *LevelDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LevelDefinition) GetMinExperience() int64 {
+	if x != nil {
+		return x.MinExperience
+	}
+	return 0
+}
+
+func (x *LevelDefinition) GetPerks() []string {
+	if x != nil {
+		return x.Perks
+	}
+	return nil
+}
+
+// 等级列表请求
+type ListLevelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLevelsRequest) Reset() {
+	*x = ListLevelsRequest{}
+	mi := &file_point_v1_point_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLevelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLevelsRequest) ProtoMessage() {}
+
+func (x *ListLevelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLevelsRequest.ProtoReflect.Descriptor instead.
+func (*ListLevelsRequest) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{23}
+}
+
+// 等级列表响应
+type ListLevelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Levels        []*LevelDefinition     `protobuf:"bytes,1,rep,name=levels,proto3" json:"levels,omitempty"` // 按等级升序排列
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLevelsResponse) Reset() {
+	*x = ListLevelsResponse{}
+	mi := &file_point_v1_point_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLevelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLevelsResponse) ProtoMessage() {}
+
+func (x *ListLevelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLevelsResponse.ProtoReflect.Descriptor instead.
+func (*ListLevelsResponse) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListLevelsResponse) GetLevels() []*LevelDefinition {
+	if x != nil {
+		return x.Levels
+	}
+	return nil
+}
+
+// 排行榜查询请求
+type GetLeaderboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metric        LeaderboardMetric      `protobuf:"varint,1,opt,name=metric,proto3,enum=mundo.system.point.LeaderboardMetric" json:"metric,omitempty"`
+	Period        LeaderboardPeriod      `protobuf:"varint,2,opt,name=period,proto3,enum=mundo.system.point.LeaderboardPeriod" json:"period,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`   // 返回条数，默认 20，最大 100
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"` // 跳过的条数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLeaderboardRequest) Reset() {
+	*x = GetLeaderboardRequest{}
+	mi := &file_point_v1_point_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardRequest) ProtoMessage() {}
+
+func (x *GetLeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetLeaderboardRequest) GetMetric() LeaderboardMetric {
+	if x != nil {
+		return x.Metric
+	}
+	return LeaderboardMetric_METRIC_POINTS
+}
+
+func (x *GetLeaderboardRequest) GetPeriod() LeaderboardPeriod {
+	if x != nil {
+		return x.Period
+	}
+	return LeaderboardPeriod_PERIOD_ALL_TIME
+}
+
+func (x *GetLeaderboardRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetLeaderboardRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// 排行榜条目
+type LeaderboardEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rank          int64                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"` // 名次，从 1 开始
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Level         int32                  `protobuf:"varint,4,opt,name=level,proto3" json:"level,omitempty"`
+	Value         int64                  `protobuf:"varint,5,opt,name=value,proto3" json:"value,omitempty"` // 指标数值
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardEntry) Reset() {
+	*x = LeaderboardEntry{}
+	mi := &file_point_v1_point_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEntry) ProtoMessage() {}
+
+func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *LeaderboardEntry) GetRank() int64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// 排行榜查询响应
+type GetLeaderboardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LeaderboardEntry    `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	MyEntry       *LeaderboardEntry      `protobuf:"bytes,2,opt,name=my_entry,json=myEntry,proto3" json:"my_entry,omitempty"` // 当前用户的名次，不在榜上时为空
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLeaderboardResponse) Reset() {
+	*x = GetLeaderboardResponse{}
+	mi := &file_point_v1_point_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLeaderboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardResponse) ProtoMessage() {}
+
+func (x *GetLeaderboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardResponse.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardResponse) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetLeaderboardResponse) GetEntries() []*LeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetLeaderboardResponse) GetMyEntry() *LeaderboardEntry {
+	if x != nil {
+		return x.MyEntry
+	}
+	return nil
+}
+
+// 积分商城商品
+type RewardItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price         int64                  `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`                                     // 单价（积分），最大 1000000000
+	Stock         int64                  `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"`                                     // 剩余库存
+	PerUserLimit  int64                  `protobuf:"varint,6,opt,name=per_user_limit,json=perUserLimit,proto3" json:"per_user_limit,omitempty"` // 每个用户最多兑换的数量，0 表示不限
+	StartTime     int64                  `protobuf:"varint,7,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`            // 开始兑换时间（Unix 秒），0 表示不限
+	EndTime       int64                  `protobuf:"varint,8,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`                  // 结束兑换时间（Unix 秒，不包含），0 表示不限
+	Active        bool                   `protobuf:"varint,9,opt,name=active,proto3" json:"active,omitempty"`                                   // 是否上架
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RewardItem) Reset() {
+	*x = RewardItem{}
+	mi := &file_point_v1_point_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RewardItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewardItem) ProtoMessage() {}
+
+func (x *RewardItem) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewardItem.ProtoReflect.Descriptor instead.
+func (*RewardItem) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RewardItem) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RewardItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RewardItem) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *RewardItem) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *RewardItem) GetStock() int64 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *RewardItem) GetPerUserLimit() int64 {
+	if x != nil {
+		return x.PerUserLimit
+	}
+	return 0
+}
+
+func (x *RewardItem) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *RewardItem) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *RewardItem) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+// 商品列表请求
+type ListRewardItemsRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	IncludeUnavailable bool                   `protobuf:"varint,1,opt,name=include_unavailable,json=includeUnavailable,proto3" json:"include_unavailable,omitempty"` // 是否包含未上架和不在兑换时间内的商品，需要管理员权限
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ListRewardItemsRequest) Reset() {
+	*x = ListRewardItemsRequest{}
+	mi := &file_point_v1_point_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRewardItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRewardItemsRequest) ProtoMessage() {}
+
+func (x *ListRewardItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRewardItemsRequest.ProtoReflect.Descriptor instead.
+func (*ListRewardItemsRequest) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListRewardItemsRequest) GetIncludeUnavailable() bool {
+	if x != nil {
+		return x.IncludeUnavailable
+	}
+	return false
+}
+
+// 商品列表响应
+type ListRewardItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*RewardItem          `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRewardItemsResponse) Reset() {
+	*x = ListRewardItemsResponse{}
+	mi := &file_point_v1_point_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRewardItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRewardItemsResponse) ProtoMessage() {}
+
+func (x *ListRewardItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRewardItemsResponse.ProtoReflect.Descriptor instead.
+func (*ListRewardItemsResponse) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListRewardItemsResponse) GetItems() []*RewardItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// 新建或更新商品响应
+type RewardItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ErrorCode     ErrorCode              `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=mundo.system.point.ErrorCode" json:"error_code,omitempty"`
+	Item          *RewardItem            `protobuf:"bytes,4,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RewardItemResponse) Reset() {
+	*x = RewardItemResponse{}
+	mi := &file_point_v1_point_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RewardItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewardItemResponse) ProtoMessage() {}
+
+func (x *RewardItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewardItemResponse.ProtoReflect.Descriptor instead.
+func (*RewardItemResponse) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RewardItemResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RewardItemResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RewardItemResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_UNKNOWN_ERROR
+}
+
+func (x *RewardItemResponse) GetItem() *RewardItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+// 兑换商品请求，兑换人为当前登录用户
+type RedeemItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        int64                  `protobuf:"varint,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Quantity      int64                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"` // 兑换数量，默认 1，最大 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemItemRequest) Reset() {
+	*x = RedeemItemRequest{}
+	mi := &file_point_v1_point_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemItemRequest) ProtoMessage() {}
+
+func (x *RedeemItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemItemRequest.ProtoReflect.Descriptor instead.
+func (*RedeemItemRequest) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RedeemItemRequest) GetItemId() int64 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+func (x *RedeemItemRequest) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// 兑换订单
+type RedeemOrder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // 订单号，同时记录在扣除积分记录的 ref_id 中
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ItemId        int64                  `protobuf:"varint,4,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	ItemName      string                 `protobuf:"bytes,5,opt,name=item_name,json=itemName,proto3" json:"item_name,omitempty"`
+	Quantity      int64                  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Points        int64                  `protobuf:"varint,7,opt,name=points,proto3" json:"points,omitempty"`                              // 花费的积分
+	CreatedAt     int64                  `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`       // 兑换时间（Unix 秒）
+	CancelledAt   int64                  `protobuf:"varint,9,opt,name=cancelled_at,json=cancelledAt,proto3" json:"cancelled_at,omitempty"` // 冲正扣除积分的记录时取消订单的时间（Unix 秒），未取消时为 0
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemOrder) Reset() {
+	*x = RedeemOrder{}
+	mi := &file_point_v1_point_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemOrder) ProtoMessage() {}
+
+func (x *RedeemOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemOrder.ProtoReflect.Descriptor instead.
+func (*RedeemOrder) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RedeemOrder) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RedeemOrder) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RedeemOrder) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RedeemOrder) GetItemId() int64 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+func (x *RedeemOrder) GetItemName() string {
+	if x != nil {
+		return x.ItemName
+	}
+	return ""
+}
+
+func (x *RedeemOrder) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *RedeemOrder) GetPoints() int64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *RedeemOrder) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *RedeemOrder) GetCancelledAt() int64 {
+	if x != nil {
+		return x.CancelledAt
+	}
+	return 0
+}
+
+// 兑换商品响应
+type RedeemItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ErrorCode     ErrorCode              `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=mundo.system.point.ErrorCode" json:"error_code,omitempty"`
+	Order         *RedeemOrder           `protobuf:"bytes,4,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemItemResponse) Reset() {
+	*x = RedeemItemResponse{}
+	mi := &file_point_v1_point_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemItemResponse) ProtoMessage() {}
+
+func (x *RedeemItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemItemResponse.ProtoReflect.Descriptor instead.
+func (*RedeemItemResponse) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RedeemItemResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RedeemItemResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RedeemItemResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_UNKNOWN_ERROR
+}
+
+func (x *RedeemItemResponse) GetOrder() *RedeemOrder {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// 兑换订单查询请求
+type ListRedeemOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`        // 按用户过滤，为空表示所有用户
+	ItemId        int64                  `protobuf:"varint,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`       // 按商品过滤，0 表示所有商品
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`                      // 分页游标，首次查询留空
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // 每页条数，默认 20，最大 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRedeemOrdersRequest) Reset() {
+	*x = ListRedeemOrdersRequest{}
+	mi := &file_point_v1_point_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRedeemOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRedeemOrdersRequest) ProtoMessage() {}
+
+func (x *ListRedeemOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRedeemOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListRedeemOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListRedeemOrdersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListRedeemOrdersRequest) GetItemId() int64 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+func (x *ListRedeemOrdersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListRedeemOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// 兑换订单查询响应
+type ListRedeemOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*RedeemOrder         `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // 下一页游标，为空表示没有更多数据
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRedeemOrdersResponse) Reset() {
+	*x = ListRedeemOrdersResponse{}
+	mi := &file_point_v1_point_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRedeemOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRedeemOrdersResponse) ProtoMessage() {}
+
+func (x *ListRedeemOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRedeemOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListRedeemOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListRedeemOrdersResponse) GetOrders() []*RedeemOrder {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *ListRedeemOrdersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// 管理员调整积分请求
+type AdminAdjustPointsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                             // 被调整的用户ID
+	DeltaPoints     int64                  `protobuf:"varint,2,opt,name=delta_points,json=deltaPoints,proto3" json:"delta_points,omitempty"`             // 积分变化量（正加负扣）
+	DeltaExperience int64                  `protobuf:"varint,3,opt,name=delta_experience,json=deltaExperience,proto3" json:"delta_experience,omitempty"` // 经验变化量
+	Reason          string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`                                           // 调整原因，必填，最多512个字符
+	Ticket          string                 `protobuf:"bytes,5,opt,name=ticket,proto3" json:"ticket,omitempty"`                                           // 工单号，必填，最多128个字符
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AdminAdjustPointsRequest) Reset() {
+	*x = AdminAdjustPointsRequest{}
+	mi := &file_point_v1_point_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminAdjustPointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminAdjustPointsRequest) ProtoMessage() {}
+
+func (x *AdminAdjustPointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminAdjustPointsRequest.ProtoReflect.Descriptor instead.
+func (*AdminAdjustPointsRequest) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *AdminAdjustPointsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AdminAdjustPointsRequest) GetDeltaPoints() int64 {
+	if x != nil {
+		return x.DeltaPoints
 	}
 	return 0
 }
 
-func (x *SignResponse) GetContinuousSignDays() int32 {
+func (x *AdminAdjustPointsRequest) GetDeltaExperience() int64 {
 	if x != nil {
-		return x.ContinuousSignDays
+		return x.DeltaExperience
 	}
 	return 0
 }
 
-// 后台统计数据
-type AdminStats struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	LevelDistribution []*LevelDistribution   `protobuf:"bytes,1,rep,name=level_distribution,json=levelDistribution,proto3" json:"level_distribution,omitempty"`
-	AvgPoints         float32                `protobuf:"fixed32,2,opt,name=avg_points,json=avgPoints,proto3" json:"avg_points,omitempty"`
-	MonthlyPointsUsed int64                  `protobuf:"varint,3,opt,name=monthly_points_used,json=monthlyPointsUsed,proto3" json:"monthly_points_used,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *AdminAdjustPointsRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
 }
 
-func (x *AdminStats) Reset() {
-	*x = AdminStats{}
-	mi := &file_point_v1_point_proto_msgTypes[7]
+func (x *AdminAdjustPointsRequest) GetTicket() string {
+	if x != nil {
+		return x.Ticket
+	}
+	return ""
+}
+
+// 管理员调整积分响应
+type AdminAdjustPointsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ErrorCode     ErrorCode              `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=mundo.system.point.ErrorCode" json:"error_code,omitempty"`
+	AuditId       int64                  `protobuf:"varint,4,opt,name=audit_id,json=auditId,proto3" json:"audit_id,omitempty"`            // 审计记录ID
+	RecordId      int64                  `protobuf:"varint,5,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`         // 积分记录ID
+	LevelChange   *LevelChange           `protobuf:"bytes,6,opt,name=level_change,json=levelChange,proto3" json:"level_change,omitempty"` // 等级变化，等级没有变化时为空
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminAdjustPointsResponse) Reset() {
+	*x = AdminAdjustPointsResponse{}
+	mi := &file_point_v1_point_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdminStats) String() string {
+func (x *AdminAdjustPointsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdminStats) ProtoMessage() {}
+func (*AdminAdjustPointsResponse) ProtoMessage() {}
 
-func (x *AdminStats) ProtoReflect() protoreflect.Message {
-	mi := &file_point_v1_point_proto_msgTypes[7]
+func (x *AdminAdjustPointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -587,56 +2842,84 @@ func (x *AdminStats) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdminStats.ProtoReflect.Descriptor instead.
-func (*AdminStats) Descriptor() ([]byte, []int) {
-	return file_point_v1_point_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use AdminAdjustPointsResponse.ProtoReflect.Descriptor instead.
+func (*AdminAdjustPointsResponse) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *AdminStats) GetLevelDistribution() []*LevelDistribution {
+func (x *AdminAdjustPointsResponse) GetSuccess() bool {
 	if x != nil {
-		return x.LevelDistribution
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-func (x *AdminStats) GetAvgPoints() float32 {
+func (x *AdminAdjustPointsResponse) GetMessage() string {
 	if x != nil {
-		return x.AvgPoints
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AdminAdjustPointsResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_UNKNOWN_ERROR
+}
+
+func (x *AdminAdjustPointsResponse) GetAuditId() int64 {
+	if x != nil {
+		return x.AuditId
 	}
 	return 0
 }
 
-func (x *AdminStats) GetMonthlyPointsUsed() int64 {
+func (x *AdminAdjustPointsResponse) GetRecordId() int64 {
 	if x != nil {
-		return x.MonthlyPointsUsed
+		return x.RecordId
 	}
 	return 0
 }
 
-// 等级分布
-type LevelDistribution struct {
+func (x *AdminAdjustPointsResponse) GetLevelChange() *LevelChange {
+	if x != nil {
+		return x.LevelChange
+	}
+	return nil
+}
+
+// 管理员操作审计记录
+type AdminAuditRecord struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Level         int32                  `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
-	UserCount     int64                  `protobuf:"varint,2,opt,name=user_count,json=userCount,proto3" json:"user_count,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OperatorId    string                 `protobuf:"bytes,2,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"` // 操作人用户ID
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`             // 被调整的用户ID
+	Points        int64                  `protobuf:"varint,4,opt,name=points,proto3" json:"points,omitempty"`
+	Experience    int64                  `protobuf:"varint,5,opt,name=experience,proto3" json:"experience,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Ticket        string                 `protobuf:"bytes,7,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	PointRecordId int64                  `protobuf:"varint,8,opt,name=point_record_id,json=pointRecordId,proto3" json:"point_record_id,omitempty"` // 对应的积分记录ID
+	CreatedAt     int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`               // 操作时间（Unix 秒）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LevelDistribution) Reset() {
-	*x = LevelDistribution{}
-	mi := &file_point_v1_point_proto_msgTypes[8]
+func (x *AdminAuditRecord) Reset() {
+	*x = AdminAuditRecord{}
+	mi := &file_point_v1_point_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LevelDistribution) String() string {
+func (x *AdminAuditRecord) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LevelDistribution) ProtoMessage() {}
+func (*AdminAuditRecord) ProtoMessage() {}
 
-func (x *LevelDistribution) ProtoReflect() protoreflect.Message {
-	mi := &file_point_v1_point_proto_msgTypes[8]
+func (x *AdminAuditRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -647,30 +2930,201 @@ func (x *LevelDistribution) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LevelDistribution.ProtoReflect.Descriptor instead.
-func (*LevelDistribution) Descriptor() ([]byte, []int) {
-	return file_point_v1_point_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use AdminAuditRecord.ProtoReflect.Descriptor instead.
+func (*AdminAuditRecord) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *LevelDistribution) GetLevel() int32 {
+func (x *AdminAuditRecord) GetId() int64 {
 	if x != nil {
-		return x.Level
+		return x.Id
 	}
 	return 0
 }
 
-func (x *LevelDistribution) GetUserCount() int64 {
+func (x *AdminAuditRecord) GetOperatorId() string {
 	if x != nil {
-		return x.UserCount
+		return x.OperatorId
+	}
+	return ""
+}
+
+func (x *AdminAuditRecord) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AdminAuditRecord) GetPoints() int64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *AdminAuditRecord) GetExperience() int64 {
+	if x != nil {
+		return x.Experience
+	}
+	return 0
+}
+
+func (x *AdminAuditRecord) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AdminAuditRecord) GetTicket() string {
+	if x != nil {
+		return x.Ticket
+	}
+	return ""
+}
+
+func (x *AdminAuditRecord) GetPointRecordId() int64 {
+	if x != nil {
+		return x.PointRecordId
+	}
+	return 0
+}
+
+func (x *AdminAuditRecord) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// 审计记录查询请求
+type ListAdminAuditRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OperatorId    string                 `protobuf:"bytes,1,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"` // 按操作人过滤
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`             // 按被调整的用户过滤
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`                           // 分页游标，首次查询留空
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`      // 每页条数，默认 20，最大 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAdminAuditRequest) Reset() {
+	*x = ListAdminAuditRequest{}
+	mi := &file_point_v1_point_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAdminAuditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAdminAuditRequest) ProtoMessage() {}
+
+func (x *ListAdminAuditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAdminAuditRequest.ProtoReflect.Descriptor instead.
+func (*ListAdminAuditRequest) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListAdminAuditRequest) GetOperatorId() string {
+	if x != nil {
+		return x.OperatorId
+	}
+	return ""
+}
+
+func (x *ListAdminAuditRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListAdminAuditRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListAdminAuditRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
 	}
 	return 0
 }
 
+// 审计记录查询响应
+type ListAdminAuditResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*AdminAuditRecord    `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // 下一页游标，为空表示没有更多数据
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAdminAuditResponse) Reset() {
+	*x = ListAdminAuditResponse{}
+	mi := &file_point_v1_point_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAdminAuditResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAdminAuditResponse) ProtoMessage() {}
+
+func (x *ListAdminAuditResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_point_v1_point_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAdminAuditResponse.ProtoReflect.Descriptor instead.
+func (*ListAdminAuditResponse) Descriptor() ([]byte, []int) {
+	return file_point_v1_point_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListAdminAuditResponse) GetRecords() []*AdminAuditRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *ListAdminAuditResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 var File_point_v1_point_proto protoreflect.FileDescriptor
 
 const file_point_v1_point_proto_rawDesc = "" +
 	"\n" +
-	"\x14point/v1/point.proto\x12\x12mundo.system.point\"\xab\x02\n" +
+	"\x14point/v1/point.proto\x12\x12mundo.system.point\"\xfe\x02\n" +
 	"\bUserInfo\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x1e\n" +
@@ -682,25 +3136,71 @@ const file_point_v1_point_proto_rawDesc = "" +
 	"\tis_signed\x18\x06 \x01(\bR\bisSigned\x120\n" +
 	"\x14continuous_sign_days\x18\a \x01(\x05R\x12continuousSignDays\x12&\n" +
 	"\x0ftotal_sign_days\x18\b \x01(\x05R\rtotalSignDays\x12%\n" +
-	"\x0eactivity_score\x18\t \x01(\x03R\ractivityScore\"\x94\x01\n" +
+	"\x0eactivity_score\x18\t \x01(\x03R\ractivityScore\x12'\n" +
+	"\x0fexpiring_points\x18\n" +
+	" \x01(\x03R\x0eexpiringPoints\x12(\n" +
+	"\x10next_expire_time\x18\v \x01(\x03R\x0enextExpireTime\"\xbd\x01\n" +
 	"\x13UpdatePointsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
 	"\fdelta_points\x18\x02 \x01(\x03R\vdeltaPoints\x12)\n" +
 	"\x10delta_experience\x18\x03 \x01(\x03R\x0fdeltaExperience\x12\x16\n" +
-	"\x06reason\x18\x04 \x01(\tR\x06reason\"\x82\x01\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\"\xbf\x02\n" +
+	"\x14UpdatePointsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\x0e2\x1d.mundo.system.point.ErrorCodeR\terrorCode\x12B\n" +
+	"\flevel_change\x18\x04 \x01(\v2\x1f.mundo.system.point.LevelChangeR\vlevelChange\x12%\n" +
+	"\x0egranted_points\x18\x05 \x01(\x03R\rgrantedPoints\x12-\n" +
+	"\x12granted_experience\x18\x06 \x01(\x03R\x11grantedExperience\x12\x1b\n" +
+	"\trecord_id\x18\a \x01(\x03R\brecordId\"G\n" +
+	"\vLevelChange\x12\x1b\n" +
+	"\told_level\x18\x01 \x01(\x05R\boldLevel\x12\x1b\n" +
+	"\tnew_level\x18\x02 \x01(\x05R\bnewLevel\"\x82\x01\n" +
 	"\x0eCommonResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
 	"\n" +
-	"error_code\x18\x03 \x01(\x0e2\x1d.mundo.system.point.ErrorCodeR\terrorCode\"e\n" +
+	"error_code\x18\x03 \x01(\x0e2\x1d.mundo.system.point.ErrorCodeR\terrorCode\"M\n" +
+	"\x15TransferPointsRequest\x12\x1c\n" +
+	"\n" +
+	"to_user_id\x18\x01 \x01(\tR\btoUserId\x12\x16\n" +
+	"\x06points\x18\x02 \x01(\x03R\x06points\"\xab\x01\n" +
+	"\x16TransferPointsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\x0e2\x1d.mundo.system.point.ErrorCodeR\terrorCode\x12\x1f\n" +
+	"\vtransfer_id\x18\x04 \x01(\tR\n" +
+	"transferId\"e\n" +
 	"\vLikeRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
 	"\apost_id\x18\x02 \x01(\tR\x06postId\x12$\n" +
 	"\x0etarget_user_id\x18\x03 \x01(\tR\ftargetUserId\"-\n" +
 	"\x12GetUserInfoRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"&\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"4\n" +
+	"\x17BatchGetUserInfoRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\"\xeb\x01\n" +
+	"\x18BatchGetUserInfoResponse\x12M\n" +
+	"\x05users\x18\x01 \x03(\v27.mundo.system.point.BatchGetUserInfoResponse.UsersEntryR\x05users\x12(\n" +
+	"\x10missing_user_ids\x18\x02 \x03(\tR\x0emissingUserIds\x1aV\n" +
+	"\n" +
+	"UsersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x122\n" +
+	"\x05value\x18\x02 \x01(\v2\x1c.mundo.system.point.UserInfoR\x05value:\x028\x01\"&\n" +
 	"\vSignRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xea\x01\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"H\n" +
+	"\x11EnsureUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\"\xd2\x01\n" +
+	"\x12EnsureUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\x0e2\x1d.mundo.system.point.ErrorCodeR\terrorCode\x120\n" +
+	"\x04user\x18\x04 \x01(\v2\x1c.mundo.system.point.UserInfoR\x04user\x12\x18\n" +
+	"\acreated\x18\x05 \x01(\bR\acreated\"\xe0\x02\n" +
 	"\fSignResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
@@ -710,7 +3210,10 @@ const file_point_v1_point_proto_rawDesc = "" +
 	"\n" +
 	"experience\x18\x05 \x01(\x03R\n" +
 	"experience\x120\n" +
-	"\x14continuous_sign_days\x18\x06 \x01(\x05R\x12continuousSignDays\"\xb1\x01\n" +
+	"\x14continuous_sign_days\x18\x06 \x01(\x05R\x12continuousSignDays\x12\x1a\n" +
+	"\bactivity\x18\a \x01(\x03R\bactivity\x12\x14\n" +
+	"\x05level\x18\b \x01(\x05R\x05level\x12B\n" +
+	"\flevel_change\x18\t \x01(\v2\x1f.mundo.system.point.LevelChangeR\vlevelChange\"\xb1\x01\n" +
 	"\n" +
 	"AdminStats\x12T\n" +
 	"\x12level_distribution\x18\x01 \x03(\v2%.mundo.system.point.LevelDistributionR\x11levelDistribution\x12\x1d\n" +
@@ -720,20 +3223,203 @@ const file_point_v1_point_proto_rawDesc = "" +
 	"\x11LevelDistribution\x12\x14\n" +
 	"\x05level\x18\x01 \x01(\x05R\x05level\x12\x1d\n" +
 	"\n" +
-	"user_count\x18\x02 \x01(\x03R\tuserCount*r\n" +
+	"user_count\x18\x02 \x01(\x03R\tuserCount\"\xa6\x02\n" +
+	"\vPointRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06points\x18\x03 \x01(\x03R\x06points\x12\x1e\n" +
+	"\n" +
+	"experience\x18\x04 \x01(\x03R\n" +
+	"experience\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1f\n" +
+	"\vtransfer_id\x18\a \x01(\tR\n" +
+	"transferId\x12&\n" +
+	"\x0frelated_user_id\x18\b \x01(\tR\rrelatedUserId\x12\x15\n" +
+	"\x06ref_id\x18\t \x01(\tR\x05refId\x12\x1f\n" +
+	"\vreverses_id\x18\n" +
+	" \x01(\x03R\n" +
+	"reversesId\"8\n" +
+	"\x19ReversePointRecordRequest\x12\x1b\n" +
+	"\trecord_id\x18\x01 \x01(\x03R\brecordId\"\x8b\x02\n" +
+	"\x1aReversePointRecordResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\x0e2\x1d.mundo.system.point.ErrorCodeR\terrorCode\x127\n" +
+	"\x06record\x18\x04 \x01(\v2\x1f.mundo.system.point.PointRecordR\x06record\x12B\n" +
+	"\flevel_change\x18\x05 \x01(\v2\x1f.mundo.system.point.LevelChangeR\vlevelChange\"\xfb\x01\n" +
+	"\x17ListPointRecordsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x05 \x01(\x03R\aendTime\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12@\n" +
+	"\tdirection\x18\a \x01(\x0e2\".mundo.system.point.PointDirectionR\tdirection\"v\n" +
+	"\x18ListPointRecordsResponse\x129\n" +
+	"\arecords\x18\x01 \x03(\v2\x1f.mundo.system.point.PointRecordR\arecords\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"x\n" +
+	"\x0fLevelDefinition\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\x05R\x05level\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\x0emin_experience\x18\x03 \x01(\x03R\rminExperience\x12\x14\n" +
+	"\x05perks\x18\x04 \x03(\tR\x05perks\"\x13\n" +
+	"\x11ListLevelsRequest\"Q\n" +
+	"\x12ListLevelsResponse\x12;\n" +
+	"\x06levels\x18\x01 \x03(\v2#.mundo.system.point.LevelDefinitionR\x06levels\"\xc3\x01\n" +
+	"\x15GetLeaderboardRequest\x12=\n" +
+	"\x06metric\x18\x01 \x01(\x0e2%.mundo.system.point.LeaderboardMetricR\x06metric\x12=\n" +
+	"\x06period\x18\x02 \x01(\x0e2%.mundo.system.point.LeaderboardPeriodR\x06period\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"\x87\x01\n" +
+	"\x10LeaderboardEntry\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x03R\x04rank\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x14\n" +
+	"\x05level\x18\x04 \x01(\x05R\x05level\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\x03R\x05value\"\x99\x01\n" +
+	"\x16GetLeaderboardResponse\x12>\n" +
+	"\aentries\x18\x01 \x03(\v2$.mundo.system.point.LeaderboardEntryR\aentries\x12?\n" +
+	"\bmy_entry\x18\x02 \x01(\v2$.mundo.system.point.LeaderboardEntryR\amyEntry\"\xf6\x01\n" +
+	"\n" +
+	"RewardItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x03R\x05price\x12\x14\n" +
+	"\x05stock\x18\x05 \x01(\x03R\x05stock\x12$\n" +
+	"\x0eper_user_limit\x18\x06 \x01(\x03R\fperUserLimit\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\a \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\b \x01(\x03R\aendTime\x12\x16\n" +
+	"\x06active\x18\t \x01(\bR\x06active\"I\n" +
+	"\x16ListRewardItemsRequest\x12/\n" +
+	"\x13include_unavailable\x18\x01 \x01(\bR\x12includeUnavailable\"O\n" +
+	"\x17ListRewardItemsResponse\x124\n" +
+	"\x05items\x18\x01 \x03(\v2\x1e.mundo.system.point.RewardItemR\x05items\"\xba\x01\n" +
+	"\x12RewardItemResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\x0e2\x1d.mundo.system.point.ErrorCodeR\terrorCode\x122\n" +
+	"\x04item\x18\x04 \x01(\v2\x1e.mundo.system.point.RewardItemR\x04item\"H\n" +
+	"\x11RedeemItemRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\x03R\x06itemId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x03R\bquantity\"\xfd\x01\n" +
+	"\vRedeemOrder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x17\n" +
+	"\aitem_id\x18\x04 \x01(\x03R\x06itemId\x12\x1b\n" +
+	"\titem_name\x18\x05 \x01(\tR\bitemName\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x03R\bquantity\x12\x16\n" +
+	"\x06points\x18\a \x01(\x03R\x06points\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\x03R\tcreatedAt\x12!\n" +
+	"\fcancelled_at\x18\t \x01(\x03R\vcancelledAt\"\xbd\x01\n" +
+	"\x12RedeemItemResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\x0e2\x1d.mundo.system.point.ErrorCodeR\terrorCode\x125\n" +
+	"\x05order\x18\x04 \x01(\v2\x1f.mundo.system.point.RedeemOrderR\x05order\"\x80\x01\n" +
+	"\x17ListRedeemOrdersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\x03R\x06itemId\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"t\n" +
+	"\x18ListRedeemOrdersResponse\x127\n" +
+	"\x06orders\x18\x01 \x03(\v2\x1f.mundo.system.point.RedeemOrderR\x06orders\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\xb1\x01\n" +
+	"\x18AdminAdjustPointsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\fdelta_points\x18\x02 \x01(\x03R\vdeltaPoints\x12)\n" +
+	"\x10delta_experience\x18\x03 \x01(\x03R\x0fdeltaExperience\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06ticket\x18\x05 \x01(\tR\x06ticket\"\x89\x02\n" +
+	"\x19AdminAdjustPointsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\x0e2\x1d.mundo.system.point.ErrorCodeR\terrorCode\x12\x19\n" +
+	"\baudit_id\x18\x04 \x01(\x03R\aauditId\x12\x1b\n" +
+	"\trecord_id\x18\x05 \x01(\x03R\brecordId\x12B\n" +
+	"\flevel_change\x18\x06 \x01(\v2\x1f.mundo.system.point.LevelChangeR\vlevelChange\"\x8b\x02\n" +
+	"\x10AdminAuditRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
+	"\voperator_id\x18\x02 \x01(\tR\n" +
+	"operatorId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06points\x18\x04 \x01(\x03R\x06points\x12\x1e\n" +
+	"\n" +
+	"experience\x18\x05 \x01(\x03R\n" +
+	"experience\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06ticket\x18\a \x01(\tR\x06ticket\x12&\n" +
+	"\x0fpoint_record_id\x18\b \x01(\x03R\rpointRecordId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\"\x86\x01\n" +
+	"\x15ListAdminAuditRequest\x12\x1f\n" +
+	"\voperator_id\x18\x01 \x01(\tR\n" +
+	"operatorId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"y\n" +
+	"\x16ListAdminAuditResponse\x12>\n" +
+	"\arecords\x18\x01 \x03(\v2$.mundo.system.point.AdminAuditRecordR\arecords\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor*L\n" +
+	"\x0ePointDirection\x12\x11\n" +
+	"\rDIRECTION_ALL\x10\x00\x12\x12\n" +
+	"\x0eDIRECTION_EARN\x10\x01\x12\x13\n" +
+	"\x0fDIRECTION_SPEND\x10\x02*y\n" +
+	"\x11LeaderboardMetric\x12\x11\n" +
+	"\rMETRIC_POINTS\x10\x00\x12\x15\n" +
+	"\x11METRIC_EXPERIENCE\x10\x01\x12\x19\n" +
+	"\x15METRIC_ACTIVITY_SCORE\x10\x02\x12\x1f\n" +
+	"\x1bMETRIC_CONTINUOUS_SIGN_DAYS\x10\x03*K\n" +
+	"\x11LeaderboardPeriod\x12\x13\n" +
+	"\x0fPERIOD_ALL_TIME\x10\x00\x12\x0f\n" +
+	"\vPERIOD_WEEK\x10\x01\x12\x10\n" +
+	"\fPERIOD_MONTH\x10\x02*\x98\x01\n" +
 	"\tErrorCode\x12\x11\n" +
 	"\rUNKNOWN_ERROR\x10\x00\x12\x17\n" +
 	"\x13POINTS_INSUFFICIENT\x10\x01\x12\x14\n" +
 	"\x10OPERATION_FAILED\x10\x02\x12\x13\n" +
 	"\x0fINVALID_REQUEST\x10\x03\x12\x0e\n" +
 	"\n" +
-	"NONE_ERROR\x10\x042\xc6\x03\n" +
-	"\vUserService\x12K\n" +
-	"\x04Sign\x12\x1f.mundo.system.point.SignRequest\x1a\".mundo.system.point.CommonResponse\x12h\n" +
-	"\x19UpdatePointsAndExperience\x12'.mundo.system.point.UpdatePointsRequest\x1a\".mundo.system.point.CommonResponse\x12S\n" +
-	"\vGetUserInfo\x12&.mundo.system.point.GetUserInfoRequest\x1a\x1c.mundo.system.point.UserInfo\x12R\n" +
-	"\vProcessLike\x12\x1f.mundo.system.point.LikeRequest\x1a\".mundo.system.point.CommonResponse\x12W\n" +
-	"\rGetAdminStats\x12&.mundo.system.point.GetUserInfoRequest\x1a\x1e.mundo.system.point.AdminStatsB\xbe\x01\n" +
+	"NONE_ERROR\x10\x04\x12\x12\n" +
+	"\x0eLIMIT_EXCEEDED\x10\x05\x12\x10\n" +
+	"\fOUT_OF_STOCK\x10\x062\xca\x0f\n" +
+	"\vUserService\x12I\n" +
+	"\x04Sign\x12\x1f.mundo.system.point.SignRequest\x1a .mundo.system.point.SignResponse\x12n\n" +
+	"\x19UpdatePointsAndExperience\x12'.mundo.system.point.UpdatePointsRequest\x1a(.mundo.system.point.UpdatePointsResponse\x12[\n" +
+	"\n" +
+	"EnsureUser\x12%.mundo.system.point.EnsureUserRequest\x1a&.mundo.system.point.EnsureUserResponse\x12S\n" +
+	"\vGetUserInfo\x12&.mundo.system.point.GetUserInfoRequest\x1a\x1c.mundo.system.point.UserInfo\x12m\n" +
+	"\x10BatchGetUserInfo\x12+.mundo.system.point.BatchGetUserInfoRequest\x1a,.mundo.system.point.BatchGetUserInfoResponse\x12R\n" +
+	"\vProcessLike\x12\x1f.mundo.system.point.LikeRequest\x1a\".mundo.system.point.CommonResponse\x12T\n" +
+	"\rUnprocessLike\x12\x1f.mundo.system.point.LikeRequest\x1a\".mundo.system.point.CommonResponse\x12W\n" +
+	"\rGetAdminStats\x12&.mundo.system.point.GetUserInfoRequest\x1a\x1e.mundo.system.point.AdminStats\x12m\n" +
+	"\x10ListPointRecords\x12+.mundo.system.point.ListPointRecordsRequest\x1a,.mundo.system.point.ListPointRecordsResponse\x12s\n" +
+	"\x12ReversePointRecord\x12-.mundo.system.point.ReversePointRecordRequest\x1a..mundo.system.point.ReversePointRecordResponse\x12[\n" +
+	"\n" +
+	"ListLevels\x12%.mundo.system.point.ListLevelsRequest\x1a&.mundo.system.point.ListLevelsResponse\x12g\n" +
+	"\x0eGetLeaderboard\x12).mundo.system.point.GetLeaderboardRequest\x1a*.mundo.system.point.GetLeaderboardResponse\x12g\n" +
+	"\x0eTransferPoints\x12).mundo.system.point.TransferPointsRequest\x1a*.mundo.system.point.TransferPointsResponse\x12j\n" +
+	"\x0fListRewardItems\x12*.mundo.system.point.ListRewardItemsRequest\x1a+.mundo.system.point.ListRewardItemsResponse\x12[\n" +
+	"\n" +
+	"RedeemItem\x12%.mundo.system.point.RedeemItemRequest\x1a&.mundo.system.point.RedeemItemResponse\x12Z\n" +
+	"\x10CreateRewardItem\x12\x1e.mundo.system.point.RewardItem\x1a&.mundo.system.point.RewardItemResponse\x12Z\n" +
+	"\x10UpdateRewardItem\x12\x1e.mundo.system.point.RewardItem\x1a&.mundo.system.point.RewardItemResponse\x12m\n" +
+	"\x10ListRedeemOrders\x12+.mundo.system.point.ListRedeemOrdersRequest\x1a,.mundo.system.point.ListRedeemOrdersResponse\x12p\n" +
+	"\x11AdminAdjustPoints\x12,.mundo.system.point.AdminAdjustPointsRequest\x1a-.mundo.system.point.AdminAdjustPointsResponse\x12g\n" +
+	"\x0eListAdminAudit\x12).mundo.system.point.ListAdminAuditRequest\x1a*.mundo.system.point.ListAdminAuditResponseB\xbe\x01\n" +
 	"\x16com.mundo.system.pointB\n" +
 	"PointProtoP\x01Z.github.com/trancecho/internal-message/point/v1\xa2\x02\x03MSP\xaa\x02\x12Mundo.System.Point\xca\x02\x12Mundo\\System\\Point\xe2\x02\x1eMundo\\System\\Point\\GPBMetadata\xea\x02\x14Mundo::System::Pointb\x06proto3"
 
@@ -749,39 +3435,133 @@ func file_point_v1_point_proto_rawDescGZIP() []byte {
 	return file_point_v1_point_proto_rawDescData
 }
 
-var file_point_v1_point_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_point_v1_point_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_point_v1_point_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_point_v1_point_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_point_v1_point_proto_goTypes = []any{
-	(ErrorCode)(0),              // 0: mundo.system.point.ErrorCode
-	(*UserInfo)(nil),            // 1: mundo.system.point.UserInfo
-	(*UpdatePointsRequest)(nil), // 2: mundo.system.point.UpdatePointsRequest
-	(*CommonResponse)(nil),      // 3: mundo.system.point.CommonResponse
-	(*LikeRequest)(nil),         // 4: mundo.system.point.LikeRequest
-	(*GetUserInfoRequest)(nil),  // 5: mundo.system.point.GetUserInfoRequest
-	(*SignRequest)(nil),         // 6: mundo.system.point.SignRequest
-	(*SignResponse)(nil),        // 7: mundo.system.point.SignResponse
-	(*AdminStats)(nil),          // 8: mundo.system.point.AdminStats
-	(*LevelDistribution)(nil),   // 9: mundo.system.point.LevelDistribution
+	(PointDirection)(0),                // 0: mundo.system.point.PointDirection
+	(LeaderboardMetric)(0),             // 1: mundo.system.point.LeaderboardMetric
+	(LeaderboardPeriod)(0),             // 2: mundo.system.point.LeaderboardPeriod
+	(ErrorCode)(0),                     // 3: mundo.system.point.ErrorCode
+	(*UserInfo)(nil),                   // 4: mundo.system.point.UserInfo
+	(*UpdatePointsRequest)(nil),        // 5: mundo.system.point.UpdatePointsRequest
+	(*UpdatePointsResponse)(nil),       // 6: mundo.system.point.UpdatePointsResponse
+	(*LevelChange)(nil),                // 7: mundo.system.point.LevelChange
+	(*CommonResponse)(nil),             // 8: mundo.system.point.CommonResponse
+	(*TransferPointsRequest)(nil),      // 9: mundo.system.point.TransferPointsRequest
+	(*TransferPointsResponse)(nil),     // 10: mundo.system.point.TransferPointsResponse
+	(*LikeRequest)(nil),                // 11: mundo.system.point.LikeRequest
+	(*GetUserInfoRequest)(nil),         // 12: mundo.system.point.GetUserInfoRequest
+	(*BatchGetUserInfoRequest)(nil),    // 13: mundo.system.point.BatchGetUserInfoRequest
+	(*BatchGetUserInfoResponse)(nil),   // 14: mundo.system.point.BatchGetUserInfoResponse
+	(*SignRequest)(nil),                // 15: mundo.system.point.SignRequest
+	(*EnsureUserRequest)(nil),          // 16: mundo.system.point.EnsureUserRequest
+	(*EnsureUserResponse)(nil),         // 17: mundo.system.point.EnsureUserResponse
+	(*SignResponse)(nil),               // 18: mundo.system.point.SignResponse
+	(*AdminStats)(nil),                 // 19: mundo.system.point.AdminStats
+	(*LevelDistribution)(nil),          // 20: mundo.system.point.LevelDistribution
+	(*PointRecord)(nil),                // 21: mundo.system.point.PointRecord
+	(*ReversePointRecordRequest)(nil),  // 22: mundo.system.point.ReversePointRecordRequest
+	(*ReversePointRecordResponse)(nil), // 23: mundo.system.point.ReversePointRecordResponse
+	(*ListPointRecordsRequest)(nil),    // 24: mundo.system.point.ListPointRecordsRequest
+	(*ListPointRecordsResponse)(nil),   // 25: mundo.system.point.ListPointRecordsResponse
+	(*LevelDefinition)(nil),            // 26: mundo.system.point.LevelDefinition
+	(*ListLevelsRequest)(nil),          // 27: mundo.system.point.ListLevelsRequest
+	(*ListLevelsResponse)(nil),         // 28: mundo.system.point.ListLevelsResponse
+	(*GetLeaderboardRequest)(nil),      // 29: mundo.system.point.GetLeaderboardRequest
+	(*LeaderboardEntry)(nil),           // 30: mundo.system.point.LeaderboardEntry
+	(*GetLeaderboardResponse)(nil),     // 31: mundo.system.point.GetLeaderboardResponse
+	(*RewardItem)(nil),                 // 32: mundo.system.point.RewardItem
+	(*ListRewardItemsRequest)(nil),     // 33: mundo.system.point.ListRewardItemsRequest
+	(*ListRewardItemsResponse)(nil),    // 34: mundo.system.point.ListRewardItemsResponse
+	(*RewardItemResponse)(nil),         // 35: mundo.system.point.RewardItemResponse
+	(*RedeemItemRequest)(nil),          // 36: mundo.system.point.RedeemItemRequest
+	(*RedeemOrder)(nil),                // 37: mundo.system.point.RedeemOrder
+	(*RedeemItemResponse)(nil),         // 38: mundo.system.point.RedeemItemResponse
+	(*ListRedeemOrdersRequest)(nil),    // 39: mundo.system.point.ListRedeemOrdersRequest
+	(*ListRedeemOrdersResponse)(nil),   // 40: mundo.system.point.ListRedeemOrdersResponse
+	(*AdminAdjustPointsRequest)(nil),   // 41: mundo.system.point.AdminAdjustPointsRequest
+	(*AdminAdjustPointsResponse)(nil),  // 42: mundo.system.point.AdminAdjustPointsResponse
+	(*AdminAuditRecord)(nil),           // 43: mundo.system.point.AdminAuditRecord
+	(*ListAdminAuditRequest)(nil),      // 44: mundo.system.point.ListAdminAuditRequest
+	(*ListAdminAuditResponse)(nil),     // 45: mundo.system.point.ListAdminAuditResponse
+	nil,                                // 46: mundo.system.point.BatchGetUserInfoResponse.UsersEntry
 }
 var file_point_v1_point_proto_depIdxs = []int32{
-	0, // 0: mundo.system.point.CommonResponse.error_code:type_name -> mundo.system.point.ErrorCode
-	0, // 1: mundo.system.point.SignResponse.error_code:type_name -> mundo.system.point.ErrorCode
-	9, // 2: mundo.system.point.AdminStats.level_distribution:type_name -> mundo.system.point.LevelDistribution
-	6, // 3: mundo.system.point.UserService.Sign:input_type -> mundo.system.point.SignRequest
-	2, // 4: mundo.system.point.UserService.UpdatePointsAndExperience:input_type -> mundo.system.point.UpdatePointsRequest
-	5, // 5: mundo.system.point.UserService.GetUserInfo:input_type -> mundo.system.point.GetUserInfoRequest
-	4, // 6: mundo.system.point.UserService.ProcessLike:input_type -> mundo.system.point.LikeRequest
-	5, // 7: mundo.system.point.UserService.GetAdminStats:input_type -> mundo.system.point.GetUserInfoRequest
-	3, // 8: mundo.system.point.UserService.Sign:output_type -> mundo.system.point.CommonResponse
-	3, // 9: mundo.system.point.UserService.UpdatePointsAndExperience:output_type -> mundo.system.point.CommonResponse
-	1, // 10: mundo.system.point.UserService.GetUserInfo:output_type -> mundo.system.point.UserInfo
-	3, // 11: mundo.system.point.UserService.ProcessLike:output_type -> mundo.system.point.CommonResponse
-	8, // 12: mundo.system.point.UserService.GetAdminStats:output_type -> mundo.system.point.AdminStats
-	8, // [8:13] is the sub-list for method output_type
-	3, // [3:8] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	3,  // 0: mundo.system.point.UpdatePointsResponse.error_code:type_name -> mundo.system.point.ErrorCode
+	7,  // 1: mundo.system.point.UpdatePointsResponse.level_change:type_name -> mundo.system.point.LevelChange
+	3,  // 2: mundo.system.point.CommonResponse.error_code:type_name -> mundo.system.point.ErrorCode
+	3,  // 3: mundo.system.point.TransferPointsResponse.error_code:type_name -> mundo.system.point.ErrorCode
+	46, // 4: mundo.system.point.BatchGetUserInfoResponse.users:type_name -> mundo.system.point.BatchGetUserInfoResponse.UsersEntry
+	3,  // 5: mundo.system.point.EnsureUserResponse.error_code:type_name -> mundo.system.point.ErrorCode
+	4,  // 6: mundo.system.point.EnsureUserResponse.user:type_name -> mundo.system.point.UserInfo
+	3,  // 7: mundo.system.point.SignResponse.error_code:type_name -> mundo.system.point.ErrorCode
+	7,  // 8: mundo.system.point.SignResponse.level_change:type_name -> mundo.system.point.LevelChange
+	20, // 9: mundo.system.point.AdminStats.level_distribution:type_name -> mundo.system.point.LevelDistribution
+	3,  // 10: mundo.system.point.ReversePointRecordResponse.error_code:type_name -> mundo.system.point.ErrorCode
+	21, // 11: mundo.system.point.ReversePointRecordResponse.record:type_name -> mundo.system.point.PointRecord
+	7,  // 12: mundo.system.point.ReversePointRecordResponse.level_change:type_name -> mundo.system.point.LevelChange
+	0,  // 13: mundo.system.point.ListPointRecordsRequest.direction:type_name -> mundo.system.point.PointDirection
+	21, // 14: mundo.system.point.ListPointRecordsResponse.records:type_name -> mundo.system.point.PointRecord
+	26, // 15: mundo.system.point.ListLevelsResponse.levels:type_name -> mundo.system.point.LevelDefinition
+	1,  // 16: mundo.system.point.GetLeaderboardRequest.metric:type_name -> mundo.system.point.LeaderboardMetric
+	2,  // 17: mundo.system.point.GetLeaderboardRequest.period:type_name -> mundo.system.point.LeaderboardPeriod
+	30, // 18: mundo.system.point.GetLeaderboardResponse.entries:type_name -> mundo.system.point.LeaderboardEntry
+	30, // 19: mundo.system.point.GetLeaderboardResponse.my_entry:type_name -> mundo.system.point.LeaderboardEntry
+	32, // 20: mundo.system.point.ListRewardItemsResponse.items:type_name -> mundo.system.point.RewardItem
+	3,  // 21: mundo.system.point.RewardItemResponse.error_code:type_name -> mundo.system.point.ErrorCode
+	32, // 22: mundo.system.point.RewardItemResponse.item:type_name -> mundo.system.point.RewardItem
+	3,  // 23: mundo.system.point.RedeemItemResponse.error_code:type_name -> mundo.system.point.ErrorCode
+	37, // 24: mundo.system.point.RedeemItemResponse.order:type_name -> mundo.system.point.RedeemOrder
+	37, // 25: mundo.system.point.ListRedeemOrdersResponse.orders:type_name -> mundo.system.point.RedeemOrder
+	3,  // 26: mundo.system.point.AdminAdjustPointsResponse.error_code:type_name -> mundo.system.point.ErrorCode
+	7,  // 27: mundo.system.point.AdminAdjustPointsResponse.level_change:type_name -> mundo.system.point.LevelChange
+	43, // 28: mundo.system.point.ListAdminAuditResponse.records:type_name -> mundo.system.point.AdminAuditRecord
+	4,  // 29: mundo.system.point.BatchGetUserInfoResponse.UsersEntry.value:type_name -> mundo.system.point.UserInfo
+	15, // 30: mundo.system.point.UserService.Sign:input_type -> mundo.system.point.SignRequest
+	5,  // 31: mundo.system.point.UserService.UpdatePointsAndExperience:input_type -> mundo.system.point.UpdatePointsRequest
+	16, // 32: mundo.system.point.UserService.EnsureUser:input_type -> mundo.system.point.EnsureUserRequest
+	12, // 33: mundo.system.point.UserService.GetUserInfo:input_type -> mundo.system.point.GetUserInfoRequest
+	13, // 34: mundo.system.point.UserService.BatchGetUserInfo:input_type -> mundo.system.point.BatchGetUserInfoRequest
+	11, // 35: mundo.system.point.UserService.ProcessLike:input_type -> mundo.system.point.LikeRequest
+	11, // 36: mundo.system.point.UserService.UnprocessLike:input_type -> mundo.system.point.LikeRequest
+	12, // 37: mundo.system.point.UserService.GetAdminStats:input_type -> mundo.system.point.GetUserInfoRequest
+	24, // 38: mundo.system.point.UserService.ListPointRecords:input_type -> mundo.system.point.ListPointRecordsRequest
+	22, // 39: mundo.system.point.UserService.ReversePointRecord:input_type -> mundo.system.point.ReversePointRecordRequest
+	27, // 40: mundo.system.point.UserService.ListLevels:input_type -> mundo.system.point.ListLevelsRequest
+	29, // 41: mundo.system.point.UserService.GetLeaderboard:input_type -> mundo.system.point.GetLeaderboardRequest
+	9,  // 42: mundo.system.point.UserService.TransferPoints:input_type -> mundo.system.point.TransferPointsRequest
+	33, // 43: mundo.system.point.UserService.ListRewardItems:input_type -> mundo.system.point.ListRewardItemsRequest
+	36, // 44: mundo.system.point.UserService.RedeemItem:input_type -> mundo.system.point.RedeemItemRequest
+	32, // 45: mundo.system.point.UserService.CreateRewardItem:input_type -> mundo.system.point.RewardItem
+	32, // 46: mundo.system.point.UserService.UpdateRewardItem:input_type -> mundo.system.point.RewardItem
+	39, // 47: mundo.system.point.UserService.ListRedeemOrders:input_type -> mundo.system.point.ListRedeemOrdersRequest
+	41, // 48: mundo.system.point.UserService.AdminAdjustPoints:input_type -> mundo.system.point.AdminAdjustPointsRequest
+	44, // 49: mundo.system.point.UserService.ListAdminAudit:input_type -> mundo.system.point.ListAdminAuditRequest
+	18, // 50: mundo.system.point.UserService.Sign:output_type -> mundo.system.point.SignResponse
+	6,  // 51: mundo.system.point.UserService.UpdatePointsAndExperience:output_type -> mundo.system.point.UpdatePointsResponse
+	17, // 52: mundo.system.point.UserService.EnsureUser:output_type -> mundo.system.point.EnsureUserResponse
+	4,  // 53: mundo.system.point.UserService.GetUserInfo:output_type -> mundo.system.point.UserInfo
+	14, // 54: mundo.system.point.UserService.BatchGetUserInfo:output_type -> mundo.system.point.BatchGetUserInfoResponse
+	8,  // 55: mundo.system.point.UserService.ProcessLike:output_type -> mundo.system.point.CommonResponse
+	8,  // 56: mundo.system.point.UserService.UnprocessLike:output_type -> mundo.system.point.CommonResponse
+	19, // 57: mundo.system.point.UserService.GetAdminStats:output_type -> mundo.system.point.AdminStats
+	25, // 58: mundo.system.point.UserService.ListPointRecords:output_type -> mundo.system.point.ListPointRecordsResponse
+	23, // 59: mundo.system.point.UserService.ReversePointRecord:output_type -> mundo.system.point.ReversePointRecordResponse
+	28, // 60: mundo.system.point.UserService.ListLevels:output_type -> mundo.system.point.ListLevelsResponse
+	31, // 61: mundo.system.point.UserService.GetLeaderboard:output_type -> mundo.system.point.GetLeaderboardResponse
+	10, // 62: mundo.system.point.UserService.TransferPoints:output_type -> mundo.system.point.TransferPointsResponse
+	34, // 63: mundo.system.point.UserService.ListRewardItems:output_type -> mundo.system.point.ListRewardItemsResponse
+	38, // 64: mundo.system.point.UserService.RedeemItem:output_type -> mundo.system.point.RedeemItemResponse
+	35, // 65: mundo.system.point.UserService.CreateRewardItem:output_type -> mundo.system.point.RewardItemResponse
+	35, // 66: mundo.system.point.UserService.UpdateRewardItem:output_type -> mundo.system.point.RewardItemResponse
+	40, // 67: mundo.system.point.UserService.ListRedeemOrders:output_type -> mundo.system.point.ListRedeemOrdersResponse
+	42, // 68: mundo.system.point.UserService.AdminAdjustPoints:output_type -> mundo.system.point.AdminAdjustPointsResponse
+	45, // 69: mundo.system.point.UserService.ListAdminAudit:output_type -> mundo.system.point.ListAdminAuditResponse
+	50, // [50:70] is the sub-list for method output_type
+	30, // [30:50] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
 }
 
 func init() { file_point_v1_point_proto_init() }
@@ -794,8 +3574,8 @@ func file_point_v1_point_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_point_v1_point_proto_rawDesc), len(file_point_v1_point_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   9,
+			NumEnums:      4,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
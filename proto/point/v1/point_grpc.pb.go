@@ -21,9 +21,24 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	UserService_Sign_FullMethodName                      = "/mundo.system.point.UserService/Sign"
 	UserService_UpdatePointsAndExperience_FullMethodName = "/mundo.system.point.UserService/UpdatePointsAndExperience"
+	UserService_EnsureUser_FullMethodName                = "/mundo.system.point.UserService/EnsureUser"
 	UserService_GetUserInfo_FullMethodName               = "/mundo.system.point.UserService/GetUserInfo"
+	UserService_BatchGetUserInfo_FullMethodName          = "/mundo.system.point.UserService/BatchGetUserInfo"
 	UserService_ProcessLike_FullMethodName               = "/mundo.system.point.UserService/ProcessLike"
+	UserService_UnprocessLike_FullMethodName             = "/mundo.system.point.UserService/UnprocessLike"
 	UserService_GetAdminStats_FullMethodName             = "/mundo.system.point.UserService/GetAdminStats"
+	UserService_ListPointRecords_FullMethodName          = "/mundo.system.point.UserService/ListPointRecords"
+	UserService_ReversePointRecord_FullMethodName        = "/mundo.system.point.UserService/ReversePointRecord"
+	UserService_ListLevels_FullMethodName                = "/mundo.system.point.UserService/ListLevels"
+	UserService_GetLeaderboard_FullMethodName            = "/mundo.system.point.UserService/GetLeaderboard"
+	UserService_TransferPoints_FullMethodName            = "/mundo.system.point.UserService/TransferPoints"
+	UserService_ListRewardItems_FullMethodName           = "/mundo.system.point.UserService/ListRewardItems"
+	UserService_RedeemItem_FullMethodName                = "/mundo.system.point.UserService/RedeemItem"
+	UserService_CreateRewardItem_FullMethodName          = "/mundo.system.point.UserService/CreateRewardItem"
+	UserService_UpdateRewardItem_FullMethodName          = "/mundo.system.point.UserService/UpdateRewardItem"
+	UserService_ListRedeemOrders_FullMethodName          = "/mundo.system.point.UserService/ListRedeemOrders"
+	UserService_AdminAdjustPoints_FullMethodName         = "/mundo.system.point.UserService/AdminAdjustPoints"
+	UserService_ListAdminAudit_FullMethodName            = "/mundo.system.point.UserService/ListAdminAudit"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -32,16 +47,48 @@ const (
 //
 // 用户服务
 type UserServiceClient interface {
-	// 用户签到
-	Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*CommonResponse, error)
+	// 用户签到，奖励由 sign_reward 配置决定，不受每日积分获取上限限制
+	Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error)
 	// 更新积分和经验
-	UpdatePointsAndExperience(ctx context.Context, in *UpdatePointsRequest, opts ...grpc.CallOption) (*CommonResponse, error)
-	// 获取用户信息
+	UpdatePointsAndExperience(ctx context.Context, in *UpdatePointsRequest, opts ...grpc.CallOption) (*UpdatePointsResponse, error)
+	// 创建用户并发放新用户积分，用户已存在时不做变更
+	EnsureUser(ctx context.Context, in *EnsureUserRequest, opts ...grpc.CallOption) (*EnsureUserResponse, error)
+	// 获取用户信息，用户不存在时返回 NotFound
 	GetUserInfo(ctx context.Context, in *GetUserInfoRequest, opts ...grpc.CallOption) (*UserInfo, error)
-	// 处理点赞
+	// 批量获取用户信息，不存在的用户单独返回
+	BatchGetUserInfo(ctx context.Context, in *BatchGetUserInfoRequest, opts ...grpc.CallOption) (*BatchGetUserInfoResponse, error)
+	// 处理点赞，被点赞者获得的积分受每日获取上限限制，超出后点赞仍然成功但不加积分，经验照常发放
 	ProcessLike(ctx context.Context, in *LikeRequest, opts ...grpc.CallOption) (*CommonResponse, error)
+	// 取消点赞，收回点赞时给被点赞者的积分
+	UnprocessLike(ctx context.Context, in *LikeRequest, opts ...grpc.CallOption) (*CommonResponse, error)
 	// 后台统计接口
 	GetAdminStats(ctx context.Context, in *GetUserInfoRequest, opts ...grpc.CallOption) (*AdminStats, error)
+	// 查询积分变更记录
+	ListPointRecords(ctx context.Context, in *ListPointRecordsRequest, opts ...grpc.CallOption) (*ListPointRecordsResponse, error)
+	// 冲正一条积分记录，如上游订单取消时退回扣除的积分，每条记录只能冲正一次，操作人记入审计记录
+	// 冲正收入只收回该记录剩余未使用、未过期的积分；冲正兑换扣除的积分时同时取消订单并恢复库存
+	// 转账、点赞、过期、管理员调整和冲正记录不能冲正
+	ReversePointRecord(ctx context.Context, in *ReversePointRecordRequest, opts ...grpc.CallOption) (*ReversePointRecordResponse, error)
+	// 获取等级定义列表
+	ListLevels(ctx context.Context, in *ListLevelsRequest, opts ...grpc.CallOption) (*ListLevelsResponse, error)
+	// 排行榜
+	GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error)
+	// 积分转账
+	TransferPoints(ctx context.Context, in *TransferPointsRequest, opts ...grpc.CallOption) (*TransferPointsResponse, error)
+	// 获取积分商城商品列表
+	ListRewardItems(ctx context.Context, in *ListRewardItemsRequest, opts ...grpc.CallOption) (*ListRewardItemsResponse, error)
+	// 兑换商品
+	RedeemItem(ctx context.Context, in *RedeemItemRequest, opts ...grpc.CallOption) (*RedeemItemResponse, error)
+	// 新建商品（管理员）
+	CreateRewardItem(ctx context.Context, in *RewardItem, opts ...grpc.CallOption) (*RewardItemResponse, error)
+	// 更新商品（管理员），按 id 覆盖全部字段
+	UpdateRewardItem(ctx context.Context, in *RewardItem, opts ...grpc.CallOption) (*RewardItemResponse, error)
+	// 查询兑换订单（管理员）
+	ListRedeemOrders(ctx context.Context, in *ListRedeemOrdersRequest, opts ...grpc.CallOption) (*ListRedeemOrdersResponse, error)
+	// 管理员调整用户积分，同时写入审计记录
+	AdminAdjustPoints(ctx context.Context, in *AdminAdjustPointsRequest, opts ...grpc.CallOption) (*AdminAdjustPointsResponse, error)
+	// 查询管理员操作审计记录
+	ListAdminAudit(ctx context.Context, in *ListAdminAuditRequest, opts ...grpc.CallOption) (*ListAdminAuditResponse, error)
 }
 
 type userServiceClient struct {
@@ -52,9 +99,9 @@ func NewUserServiceClient(cc grpc.ClientConnInterface) UserServiceClient {
 	return &userServiceClient{cc}
 }
 
-func (c *userServiceClient) Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*CommonResponse, error) {
+func (c *userServiceClient) Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(CommonResponse)
+	out := new(SignResponse)
 	err := c.cc.Invoke(ctx, UserService_Sign_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -62,9 +109,9 @@ func (c *userServiceClient) Sign(ctx context.Context, in *SignRequest, opts ...g
 	return out, nil
 }
 
-func (c *userServiceClient) UpdatePointsAndExperience(ctx context.Context, in *UpdatePointsRequest, opts ...grpc.CallOption) (*CommonResponse, error) {
+func (c *userServiceClient) UpdatePointsAndExperience(ctx context.Context, in *UpdatePointsRequest, opts ...grpc.CallOption) (*UpdatePointsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(CommonResponse)
+	out := new(UpdatePointsResponse)
 	err := c.cc.Invoke(ctx, UserService_UpdatePointsAndExperience_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -72,6 +119,16 @@ func (c *userServiceClient) UpdatePointsAndExperience(ctx context.Context, in *U
 	return out, nil
 }
 
+func (c *userServiceClient) EnsureUser(ctx context.Context, in *EnsureUserRequest, opts ...grpc.CallOption) (*EnsureUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnsureUserResponse)
+	err := c.cc.Invoke(ctx, UserService_EnsureUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) GetUserInfo(ctx context.Context, in *GetUserInfoRequest, opts ...grpc.CallOption) (*UserInfo, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UserInfo)
@@ -82,6 +139,16 @@ func (c *userServiceClient) GetUserInfo(ctx context.Context, in *GetUserInfoRequ
 	return out, nil
 }
 
+func (c *userServiceClient) BatchGetUserInfo(ctx context.Context, in *BatchGetUserInfoRequest, opts ...grpc.CallOption) (*BatchGetUserInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetUserInfoResponse)
+	err := c.cc.Invoke(ctx, UserService_BatchGetUserInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) ProcessLike(ctx context.Context, in *LikeRequest, opts ...grpc.CallOption) (*CommonResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CommonResponse)
@@ -92,6 +159,16 @@ func (c *userServiceClient) ProcessLike(ctx context.Context, in *LikeRequest, op
 	return out, nil
 }
 
+func (c *userServiceClient) UnprocessLike(ctx context.Context, in *LikeRequest, opts ...grpc.CallOption) (*CommonResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommonResponse)
+	err := c.cc.Invoke(ctx, UserService_UnprocessLike_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) GetAdminStats(ctx context.Context, in *GetUserInfoRequest, opts ...grpc.CallOption) (*AdminStats, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AdminStats)
@@ -102,22 +179,174 @@ func (c *userServiceClient) GetAdminStats(ctx context.Context, in *GetUserInfoRe
 	return out, nil
 }
 
+func (c *userServiceClient) ListPointRecords(ctx context.Context, in *ListPointRecordsRequest, opts ...grpc.CallOption) (*ListPointRecordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPointRecordsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListPointRecords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ReversePointRecord(ctx context.Context, in *ReversePointRecordRequest, opts ...grpc.CallOption) (*ReversePointRecordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReversePointRecordResponse)
+	err := c.cc.Invoke(ctx, UserService_ReversePointRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListLevels(ctx context.Context, in *ListLevelsRequest, opts ...grpc.CallOption) (*ListLevelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLevelsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListLevels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLeaderboardResponse)
+	err := c.cc.Invoke(ctx, UserService_GetLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) TransferPoints(ctx context.Context, in *TransferPointsRequest, opts ...grpc.CallOption) (*TransferPointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferPointsResponse)
+	err := c.cc.Invoke(ctx, UserService_TransferPoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListRewardItems(ctx context.Context, in *ListRewardItemsRequest, opts ...grpc.CallOption) (*ListRewardItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRewardItemsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListRewardItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RedeemItem(ctx context.Context, in *RedeemItemRequest, opts ...grpc.CallOption) (*RedeemItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RedeemItemResponse)
+	err := c.cc.Invoke(ctx, UserService_RedeemItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateRewardItem(ctx context.Context, in *RewardItem, opts ...grpc.CallOption) (*RewardItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RewardItemResponse)
+	err := c.cc.Invoke(ctx, UserService_CreateRewardItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateRewardItem(ctx context.Context, in *RewardItem, opts ...grpc.CallOption) (*RewardItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RewardItemResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateRewardItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListRedeemOrders(ctx context.Context, in *ListRedeemOrdersRequest, opts ...grpc.CallOption) (*ListRedeemOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRedeemOrdersResponse)
+	err := c.cc.Invoke(ctx, UserService_ListRedeemOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AdminAdjustPoints(ctx context.Context, in *AdminAdjustPointsRequest, opts ...grpc.CallOption) (*AdminAdjustPointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminAdjustPointsResponse)
+	err := c.cc.Invoke(ctx, UserService_AdminAdjustPoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListAdminAudit(ctx context.Context, in *ListAdminAuditRequest, opts ...grpc.CallOption) (*ListAdminAuditResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAdminAuditResponse)
+	err := c.cc.Invoke(ctx, UserService_ListAdminAudit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations should embed UnimplementedUserServiceServer
 // for forward compatibility.
 //
 // 用户服务
 type UserServiceServer interface {
-	// 用户签到
-	Sign(context.Context, *SignRequest) (*CommonResponse, error)
+	// 用户签到，奖励由 sign_reward 配置决定，不受每日积分获取上限限制
+	Sign(context.Context, *SignRequest) (*SignResponse, error)
 	// 更新积分和经验
-	UpdatePointsAndExperience(context.Context, *UpdatePointsRequest) (*CommonResponse, error)
-	// 获取用户信息
+	UpdatePointsAndExperience(context.Context, *UpdatePointsRequest) (*UpdatePointsResponse, error)
+	// 创建用户并发放新用户积分，用户已存在时不做变更
+	EnsureUser(context.Context, *EnsureUserRequest) (*EnsureUserResponse, error)
+	// 获取用户信息，用户不存在时返回 NotFound
 	GetUserInfo(context.Context, *GetUserInfoRequest) (*UserInfo, error)
-	// 处理点赞
+	// 批量获取用户信息，不存在的用户单独返回
+	BatchGetUserInfo(context.Context, *BatchGetUserInfoRequest) (*BatchGetUserInfoResponse, error)
+	// 处理点赞，被点赞者获得的积分受每日获取上限限制，超出后点赞仍然成功但不加积分，经验照常发放
 	ProcessLike(context.Context, *LikeRequest) (*CommonResponse, error)
+	// 取消点赞，收回点赞时给被点赞者的积分
+	UnprocessLike(context.Context, *LikeRequest) (*CommonResponse, error)
 	// 后台统计接口
 	GetAdminStats(context.Context, *GetUserInfoRequest) (*AdminStats, error)
+	// 查询积分变更记录
+	ListPointRecords(context.Context, *ListPointRecordsRequest) (*ListPointRecordsResponse, error)
+	// 冲正一条积分记录，如上游订单取消时退回扣除的积分，每条记录只能冲正一次，操作人记入审计记录
+	// 冲正收入只收回该记录剩余未使用、未过期的积分；冲正兑换扣除的积分时同时取消订单并恢复库存
+	// 转账、点赞、过期、管理员调整和冲正记录不能冲正
+	ReversePointRecord(context.Context, *ReversePointRecordRequest) (*ReversePointRecordResponse, error)
+	// 获取等级定义列表
+	ListLevels(context.Context, *ListLevelsRequest) (*ListLevelsResponse, error)
+	// 排行榜
+	GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error)
+	// 积分转账
+	TransferPoints(context.Context, *TransferPointsRequest) (*TransferPointsResponse, error)
+	// 获取积分商城商品列表
+	ListRewardItems(context.Context, *ListRewardItemsRequest) (*ListRewardItemsResponse, error)
+	// 兑换商品
+	RedeemItem(context.Context, *RedeemItemRequest) (*RedeemItemResponse, error)
+	// 新建商品（管理员）
+	CreateRewardItem(context.Context, *RewardItem) (*RewardItemResponse, error)
+	// 更新商品（管理员），按 id 覆盖全部字段
+	UpdateRewardItem(context.Context, *RewardItem) (*RewardItemResponse, error)
+	// 查询兑换订单（管理员）
+	ListRedeemOrders(context.Context, *ListRedeemOrdersRequest) (*ListRedeemOrdersResponse, error)
+	// 管理员调整用户积分，同时写入审计记录
+	AdminAdjustPoints(context.Context, *AdminAdjustPointsRequest) (*AdminAdjustPointsResponse, error)
+	// 查询管理员操作审计记录
+	ListAdminAudit(context.Context, *ListAdminAuditRequest) (*ListAdminAuditResponse, error)
 }
 
 // UnimplementedUserServiceServer should be embedded to have
@@ -127,21 +356,66 @@ type UserServiceServer interface {
 // pointer dereference when methods are called.
 type UnimplementedUserServiceServer struct{}
 
-func (UnimplementedUserServiceServer) Sign(context.Context, *SignRequest) (*CommonResponse, error) {
+func (UnimplementedUserServiceServer) Sign(context.Context, *SignRequest) (*SignResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Sign not implemented")
 }
-func (UnimplementedUserServiceServer) UpdatePointsAndExperience(context.Context, *UpdatePointsRequest) (*CommonResponse, error) {
+func (UnimplementedUserServiceServer) UpdatePointsAndExperience(context.Context, *UpdatePointsRequest) (*UpdatePointsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdatePointsAndExperience not implemented")
 }
+func (UnimplementedUserServiceServer) EnsureUser(context.Context, *EnsureUserRequest) (*EnsureUserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EnsureUser not implemented")
+}
 func (UnimplementedUserServiceServer) GetUserInfo(context.Context, *GetUserInfoRequest) (*UserInfo, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetUserInfo not implemented")
 }
+func (UnimplementedUserServiceServer) BatchGetUserInfo(context.Context, *BatchGetUserInfoRequest) (*BatchGetUserInfoResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchGetUserInfo not implemented")
+}
 func (UnimplementedUserServiceServer) ProcessLike(context.Context, *LikeRequest) (*CommonResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ProcessLike not implemented")
 }
+func (UnimplementedUserServiceServer) UnprocessLike(context.Context, *LikeRequest) (*CommonResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnprocessLike not implemented")
+}
 func (UnimplementedUserServiceServer) GetAdminStats(context.Context, *GetUserInfoRequest) (*AdminStats, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetAdminStats not implemented")
 }
+func (UnimplementedUserServiceServer) ListPointRecords(context.Context, *ListPointRecordsRequest) (*ListPointRecordsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPointRecords not implemented")
+}
+func (UnimplementedUserServiceServer) ReversePointRecord(context.Context, *ReversePointRecordRequest) (*ReversePointRecordResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReversePointRecord not implemented")
+}
+func (UnimplementedUserServiceServer) ListLevels(context.Context, *ListLevelsRequest) (*ListLevelsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListLevels not implemented")
+}
+func (UnimplementedUserServiceServer) GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLeaderboard not implemented")
+}
+func (UnimplementedUserServiceServer) TransferPoints(context.Context, *TransferPointsRequest) (*TransferPointsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TransferPoints not implemented")
+}
+func (UnimplementedUserServiceServer) ListRewardItems(context.Context, *ListRewardItemsRequest) (*ListRewardItemsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRewardItems not implemented")
+}
+func (UnimplementedUserServiceServer) RedeemItem(context.Context, *RedeemItemRequest) (*RedeemItemResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RedeemItem not implemented")
+}
+func (UnimplementedUserServiceServer) CreateRewardItem(context.Context, *RewardItem) (*RewardItemResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateRewardItem not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateRewardItem(context.Context, *RewardItem) (*RewardItemResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateRewardItem not implemented")
+}
+func (UnimplementedUserServiceServer) ListRedeemOrders(context.Context, *ListRedeemOrdersRequest) (*ListRedeemOrdersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRedeemOrders not implemented")
+}
+func (UnimplementedUserServiceServer) AdminAdjustPoints(context.Context, *AdminAdjustPointsRequest) (*AdminAdjustPointsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AdminAdjustPoints not implemented")
+}
+func (UnimplementedUserServiceServer) ListAdminAudit(context.Context, *ListAdminAuditRequest) (*ListAdminAuditResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAdminAudit not implemented")
+}
 func (UnimplementedUserServiceServer) testEmbeddedByValue() {}
 
 // UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -198,6 +472,24 @@ func _UserService_UpdatePointsAndExperience_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_EnsureUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnsureUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).EnsureUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_EnsureUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).EnsureUser(ctx, req.(*EnsureUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_GetUserInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetUserInfoRequest)
 	if err := dec(in); err != nil {
@@ -216,6 +508,24 @@ func _UserService_GetUserInfo_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_BatchGetUserInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetUserInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BatchGetUserInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_BatchGetUserInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BatchGetUserInfo(ctx, req.(*BatchGetUserInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_ProcessLike_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(LikeRequest)
 	if err := dec(in); err != nil {
@@ -234,6 +544,24 @@ func _UserService_ProcessLike_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_UnprocessLike_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LikeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UnprocessLike(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UnprocessLike_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UnprocessLike(ctx, req.(*LikeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_GetAdminStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetUserInfoRequest)
 	if err := dec(in); err != nil {
@@ -252,6 +580,222 @@ func _UserService_GetAdminStats_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_ListPointRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPointRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListPointRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListPointRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListPointRecords(ctx, req.(*ListPointRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ReversePointRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReversePointRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ReversePointRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ReversePointRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ReversePointRecord(ctx, req.(*ReversePointRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLevelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListLevels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListLevels(ctx, req.(*ListLevelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetLeaderboard(ctx, req.(*GetLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_TransferPoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferPointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).TransferPoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_TransferPoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).TransferPoints(ctx, req.(*TransferPointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListRewardItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRewardItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListRewardItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListRewardItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListRewardItems(ctx, req.(*ListRewardItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RedeemItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeemItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RedeemItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RedeemItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RedeemItem(ctx, req.(*RedeemItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateRewardItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RewardItem)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateRewardItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateRewardItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateRewardItem(ctx, req.(*RewardItem))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateRewardItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RewardItem)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateRewardItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateRewardItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateRewardItem(ctx, req.(*RewardItem))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListRedeemOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRedeemOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListRedeemOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListRedeemOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListRedeemOrders(ctx, req.(*ListRedeemOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AdminAdjustPoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminAdjustPointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AdminAdjustPoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AdminAdjustPoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AdminAdjustPoints(ctx, req.(*AdminAdjustPointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListAdminAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAdminAuditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListAdminAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListAdminAudit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListAdminAudit(ctx, req.(*ListAdminAuditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,18 +811,78 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdatePointsAndExperience",
 			Handler:    _UserService_UpdatePointsAndExperience_Handler,
 		},
+		{
+			MethodName: "EnsureUser",
+			Handler:    _UserService_EnsureUser_Handler,
+		},
 		{
 			MethodName: "GetUserInfo",
 			Handler:    _UserService_GetUserInfo_Handler,
 		},
+		{
+			MethodName: "BatchGetUserInfo",
+			Handler:    _UserService_BatchGetUserInfo_Handler,
+		},
 		{
 			MethodName: "ProcessLike",
 			Handler:    _UserService_ProcessLike_Handler,
 		},
+		{
+			MethodName: "UnprocessLike",
+			Handler:    _UserService_UnprocessLike_Handler,
+		},
 		{
 			MethodName: "GetAdminStats",
 			Handler:    _UserService_GetAdminStats_Handler,
 		},
+		{
+			MethodName: "ListPointRecords",
+			Handler:    _UserService_ListPointRecords_Handler,
+		},
+		{
+			MethodName: "ReversePointRecord",
+			Handler:    _UserService_ReversePointRecord_Handler,
+		},
+		{
+			MethodName: "ListLevels",
+			Handler:    _UserService_ListLevels_Handler,
+		},
+		{
+			MethodName: "GetLeaderboard",
+			Handler:    _UserService_GetLeaderboard_Handler,
+		},
+		{
+			MethodName: "TransferPoints",
+			Handler:    _UserService_TransferPoints_Handler,
+		},
+		{
+			MethodName: "ListRewardItems",
+			Handler:    _UserService_ListRewardItems_Handler,
+		},
+		{
+			MethodName: "RedeemItem",
+			Handler:    _UserService_RedeemItem_Handler,
+		},
+		{
+			MethodName: "CreateRewardItem",
+			Handler:    _UserService_CreateRewardItem_Handler,
+		},
+		{
+			MethodName: "UpdateRewardItem",
+			Handler:    _UserService_UpdateRewardItem_Handler,
+		},
+		{
+			MethodName: "ListRedeemOrders",
+			Handler:    _UserService_ListRedeemOrders_Handler,
+		},
+		{
+			MethodName: "AdminAdjustPoints",
+			Handler:    _UserService_AdminAdjustPoints_Handler,
+		},
+		{
+			MethodName: "ListAdminAudit",
+			Handler:    _UserService_ListAdminAudit_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "point/v1/point.proto",